@@ -0,0 +1,111 @@
+package cdpsdk
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BatchRequest 批量请求，将多个元素操作合并为一次 HTTP 请求
+type BatchRequest struct {
+	client     *HTTPClient
+	operations []map[string]any
+}
+
+// BatchResult 批量请求中单个操作的结果
+type BatchResult struct {
+	Success bool
+	Data    map[string]any
+	Error   string
+}
+
+// Err 返回操作的错误，成功时返回 nil
+func (r BatchResult) Err() error {
+	if r.Success {
+		return nil
+	}
+	return errors.New(r.Error)
+}
+
+// Batch 创建批量请求
+func (hc *HTTPClient) Batch() *BatchRequest {
+	return &BatchRequest{
+		client: hc,
+	}
+}
+
+// Add 添加操作，action 对应 /api/element/ 下的端点名，如 exists、text、attribute
+func (b *BatchRequest) Add(action, selector string, params map[string]any) *BatchRequest {
+	op := map[string]any{
+		"action":   action,
+		"selector": selector,
+	}
+	for k, v := range params {
+		op[k] = v
+	}
+
+	b.operations = append(b.operations, op)
+	return b
+}
+
+// Exists 添加检查元素是否存在的操作
+func (b *BatchRequest) Exists(selector string) *BatchRequest {
+	return b.Add("exists", selector, nil)
+}
+
+// Text 添加获取元素文本的操作
+func (b *BatchRequest) Text(selector string) *BatchRequest {
+	return b.Add("text", selector, nil)
+}
+
+// Attribute 添加获取元素属性的操作
+func (b *BatchRequest) Attribute(selector, attribute string) *BatchRequest {
+	return b.Add("attribute", selector, map[string]any{"attribute": attribute})
+}
+
+// Count 添加获取元素数量的操作
+func (b *BatchRequest) Count(selector string) *BatchRequest {
+	return b.Add("count", selector, nil)
+}
+
+// Len 获取已添加的操作数量
+func (b *BatchRequest) Len() int {
+	return len(b.operations)
+}
+
+// Execute 执行批量请求，结果与添加顺序一致
+// 单个操作失败只体现在对应的 BatchResult 中，不影响其他操作
+func (b *BatchRequest) Execute() ([]BatchResult, error) {
+	body := map[string]any{
+		"sessionId":  b.client.sessionId,
+		"operations": b.operations,
+	}
+
+	resp, err := b.client.doRequest("POST", "/api/batch", body)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := resp.Data["results"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("results not found in response")
+	}
+
+	if len(items) != len(b.operations) {
+		return nil, fmt.Errorf("expected %d batch results, got %d", len(b.operations), len(items))
+	}
+
+	results := make([]BatchResult, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			results[i] = BatchResult{Error: "invalid batch result"}
+			continue
+		}
+
+		results[i].Success, _ = m["success"].(bool)
+		results[i].Data, _ = m["data"].(map[string]any)
+		results[i].Error, _ = m["error"].(string)
+	}
+
+	return results, nil
+}
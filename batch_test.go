@@ -0,0 +1,52 @@
+package cdpsdk
+
+import "testing"
+
+func TestBatch(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"results": []any{
+			map[string]any{"success": true, "data": map[string]any{"exists": true}},
+			map[string]any{"success": true, "data": map[string]any{"text": "Title"}},
+			map[string]any{"success": false, "error": "element not found"},
+		}}, nil
+	})
+
+	batch := hc.Batch().Exists("#app").Text("h1").Attribute("#missing", "href")
+	if batch.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", batch.Len())
+	}
+
+	results, err := batch.Execute()
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	if results[0].Err() != nil || results[0].Data["exists"] != true {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].Err() != nil || results[1].Data["text"] != "Title" {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+	if err := results[2].Err(); err == nil || err.Error() != "element not found" {
+		t.Errorf("results[2].Err() = %v", err)
+	}
+
+	assertEndpoints(t, fb, "/api/batch")
+	assertBody(t, fb.lastCall(t), "operations", []map[string]any{
+		{"action": "exists", "selector": "#app"},
+		{"action": "text", "selector": "h1"},
+		{"action": "attribute", "selector": "#missing", "attribute": "href"},
+	})
+}
+
+func TestBatchResultCountMismatch(t *testing.T) {
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"results": []any{}}, nil
+	})
+
+	if _, err := hc.Batch().Count("li").Execute(); err == nil {
+		t.Fatal("Execute: expected error when result count does not match")
+	}
+}
@@ -0,0 +1,125 @@
+package cdpsdk
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ContextOptions 浏览器上下文选项
+type ContextOptions struct {
+	UserAgent string    `json:"userAgent,omitempty"`
+	Locale    string    `json:"locale,omitempty"` // 如 zh-CN
+	Proxy     string    `json:"proxy,omitempty"`  // 代理地址，如 http://127.0.0.1:8080
+	Viewport  *Viewport `json:"viewport,omitempty"`
+}
+
+// Viewport 视口尺寸（CSS 像素）
+type Viewport struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// BrowserContext 相互隔离的浏览器上下文，拥有独立的 cookie 和存储，用于并行登录多个账号
+type BrowserContext struct {
+	client *HTTPClient
+	id     string
+
+	mu    sync.Mutex
+	pages []*Page
+}
+
+// NewContext 在当前浏览器中创建新的上下文
+func (hc *HTTPClient) NewContext(opts ContextOptions) (*BrowserContext, error) {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"options":   opts,
+	}
+
+	resp, err := hc.doRequest("POST", "/api/context/new", body)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := resp.Data["contextId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contextId not found in response")
+	}
+
+	return &BrowserContext{
+		client: hc,
+		id:     id,
+	}, nil
+}
+
+// ID 获取上下文 ID
+func (c *BrowserContext) ID() string {
+	return c.id
+}
+
+// NewPage 在上下文中打开新页面，返回的页面使用独立的会话 ID，与其他上下文的页面互不影响
+func (c *BrowserContext) NewPage() (*Page, error) {
+	pageClient, err := c.client.newPageClient(c.id)
+	if err != nil {
+		return nil, err
+	}
+	page := NewPage(pageClient)
+
+	c.mu.Lock()
+	c.pages = append(c.pages, page)
+	c.mu.Unlock()
+
+	return page, nil
+}
+
+// newPageClient 在 contextId 对应的上下文中打开新页面，contextId 为空时使用默认上下文，返回绑定新页面会话的客户端
+func (hc *HTTPClient) newPageClient(contextId string) (*HTTPClient, error) {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+	if contextId != "" {
+		body["contextId"] = contextId
+	}
+
+	resp, err := hc.doRequest("POST", "/api/context/new-page", body)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionId, ok := resp.Data["sessionId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("sessionId not found in response")
+	}
+
+	// 与父客户端共享传输层，会话 ID 和请求头各自独立，避免一个账号设置的请求头影响其他页面
+	pageClient := *hc
+	pageClient.sessionId = sessionId
+	pageClient.headers = maps.Clone(hc.headers)
+	return &pageClient, nil
+}
+
+// Pages 获取上下文中通过 NewPage 打开的页面
+func (c *BrowserContext) Pages() []*Page {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.pages)
+}
+
+// Close 关闭上下文及其所有页面，关闭后上下文不可再使用
+func (c *BrowserContext) Close() error {
+	body := map[string]any{
+		"sessionId": c.client.sessionId,
+		"contextId": c.id,
+	}
+
+	if _, err := c.client.doRequest("POST", "/api/context/close", body); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.pages = nil
+	c.mu.Unlock()
+
+	return nil
+}
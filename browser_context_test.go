@@ -0,0 +1,81 @@
+package cdpsdk
+
+import "testing"
+
+func TestBrowserContexts(t *testing.T) {
+	var pages int
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/context/new":
+			return map[string]any{"contextId": "ctx-" + call.Body["options"].(map[string]any)["locale"].(string)}, nil
+		case "/api/context/new-page":
+			pages++
+			return map[string]any{"sessionId": call.Body["contextId"].(string) + "-page"}, nil
+		}
+		return nil, nil
+	})
+	hc.SetDefaultHeader("x-tenant", "default")
+
+	ctxA, err := hc.NewContext(ContextOptions{Locale: "zh-CN", Viewport: &Viewport{Width: 1280, Height: 720}})
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+	ctxB, err := hc.NewContext(ContextOptions{Locale: "en-US"})
+	if err != nil {
+		t.Fatalf("NewContext: %v", err)
+	}
+	assertBody(t, fb.Calls()[0], "options", map[string]any{
+		"locale":   "zh-CN",
+		"viewport": map[string]any{"width": 1280, "height": 720},
+	})
+
+	pageA, err := ctxA.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage: %v", err)
+	}
+	pageB, err := ctxB.NewPage()
+	if err != nil {
+		t.Fatalf("NewPage: %v", err)
+	}
+
+	if len(ctxA.Pages()) != 1 || ctxA.Pages()[0] != pageA {
+		t.Errorf("context A pages = %v", ctxA.Pages())
+	}
+	if len(ctxB.Pages()) != 1 || ctxB.Pages()[0] != pageB {
+		t.Errorf("context B pages = %v", ctxB.Pages())
+	}
+
+	// 每个上下文的页面使用独立的会话 ID 和请求头
+	pageA.GetClient().SetBearerToken("token-a")
+	if err := pageA.Click("#login"); err != nil {
+		t.Fatalf("Click: %v", err)
+	}
+	callA := fb.lastCall(t)
+	if err := pageB.Click("#login"); err != nil {
+		t.Fatalf("Click: %v", err)
+	}
+	callB := fb.lastCall(t)
+
+	assertBody(t, callA, "sessionId", "ctx-zh-CN-page")
+	assertBody(t, callB, "sessionId", "ctx-en-US-page")
+	if got := callA.Header.Get("Authorization"); got != "Bearer token-a" {
+		t.Errorf("page A Authorization = %q", got)
+	}
+	if got := callB.Header.Get("Authorization"); got != "" {
+		t.Errorf("page B Authorization = %q, want none", got)
+	}
+	if got := callB.Header.Get("X-Tenant"); got != "default" {
+		t.Errorf("page B X-Tenant = %q, want inherited default", got)
+	}
+	if _, ok := hc.headers["Authorization"]; ok {
+		t.Error("parent client headers were modified")
+	}
+
+	if err := ctxA.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "contextId", "ctx-zh-CN")
+	if len(ctxA.Pages()) != 0 || len(ctxB.Pages()) != 1 {
+		t.Errorf("after closing A: A has %d pages, B has %d", len(ctxA.Pages()), len(ctxB.Pages()))
+	}
+}
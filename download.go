@@ -0,0 +1,120 @@
+package cdpsdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Download 浏览器下载的文件
+type Download struct {
+	client            *HTTPClient
+	id                string
+	url               string
+	suggestedFilename string
+}
+
+// SetDownloadPath 设置当前会话的下载目录，浏览器下载的文件默认保存到该目录
+// 发送请求前在本地检查目录存在且可写，适用于客户端与浏览器服务运行在同一台机器上的场景
+func (hc *HTTPClient) SetDownloadPath(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("invalid download path: %w", err)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("invalid download path: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("invalid download path: %s is not a directory", dir)
+	}
+
+	f, err := os.CreateTemp(dir, ".cdpsdk-write-check-*")
+	if err != nil {
+		return fmt.Errorf("download path is not writable: %w", err)
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"path":      dir,
+	}
+
+	_, err = hc.doRequest("POST", "/api/browser/set-download-path", body)
+	return err
+}
+
+// ExpectDownload 等待由 trigger 触发的下载
+// trigger 中执行会触发下载的操作（如点击导出按钮），timeout 内未开始下载则返回错误，timeout 为 0 时使用服务端默认超时
+// trigger 失败时取消服务端的下载监听
+func (hc *HTTPClient) ExpectDownload(trigger func() error, timeout time.Duration) (*Download, error) {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	if _, err := hc.doRequest("POST", "/api/page/expect-download/start", body); err != nil {
+		return nil, err
+	}
+
+	if err := trigger(); err != nil {
+		if _, cancelErr := hc.doRequest("POST", "/api/page/expect-download/cancel", body); cancelErr != nil {
+			return nil, fmt.Errorf("%w (cancel download: %v)", err, cancelErr)
+		}
+		return nil, err
+	}
+
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+	resp, err := hc.doRequest("POST", "/api/page/expect-download/wait", body)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := resp.Data["downloadId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("downloadId not found in response")
+	}
+
+	download := &Download{
+		client: hc,
+		id:     id,
+	}
+	download.url, _ = resp.Data["url"].(string)
+	download.suggestedFilename, _ = resp.Data["suggestedFilename"].(string)
+
+	return download, nil
+}
+
+// URL 获取下载地址
+func (d *Download) URL() string {
+	return d.url
+}
+
+// SuggestedFilename 获取浏览器建议的文件名
+func (d *Download) SuggestedFilename() string {
+	return d.suggestedFilename
+}
+
+// Bytes 获取下载文件内容
+func (d *Download) Bytes() ([]byte, error) {
+	body := map[string]any{
+		"sessionId":  d.client.sessionId,
+		"downloadId": d.id,
+	}
+
+	return d.client.doRequestBinary("POST", "/api/page/expect-download/content", body)
+}
+
+// SaveAs 将下载文件保存到 path
+func (d *Download) SaveAs(path string) error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
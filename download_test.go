@@ -0,0 +1,121 @@
+package cdpsdk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpectDownload(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/page/expect-download/wait" {
+			return map[string]any{
+				"downloadId":        "d1",
+				"url":               "https://example.com/report.csv",
+				"suggestedFilename": "report.csv",
+			}, nil
+		}
+		return nil, nil
+	})
+	fb.binary = func(call fakeCall) ([]byte, error) {
+		if call.Endpoint != "/api/page/expect-download/content" {
+			return nil, nil
+		}
+		return []byte("id,name\n1,Go\n"), nil
+	}
+
+	download, err := hc.ExpectDownload(func() error {
+		return hc.ElementClick("#export")
+	}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("ExpectDownload: %v", err)
+	}
+	assertEndpoints(t, fb,
+		"/api/page/expect-download/start",
+		"/api/element/click",
+		"/api/page/expect-download/wait",
+	)
+	assertBody(t, fb.lastCall(t), "timeout", 5000)
+
+	if download.URL() != "https://example.com/report.csv" || download.SuggestedFilename() != "report.csv" {
+		t.Errorf("download = %q %q", download.URL(), download.SuggestedFilename())
+	}
+
+	path := filepath.Join(t.TempDir(), download.SuggestedFilename())
+	if err := download.SaveAs(path); err != nil {
+		t.Fatalf("SaveAs: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "id,name\n1,Go\n" {
+		t.Errorf("saved %q", data)
+	}
+	call := fb.lastCall(t)
+	if call.Endpoint != "/api/page/expect-download/content" {
+		t.Errorf("endpoint = %s", call.Endpoint)
+	}
+	assertBody(t, call, "downloadId", "d1")
+}
+
+func TestExpectDownloadDefaultTimeout(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"downloadId": "d1"}, nil
+	})
+
+	if _, err := hc.ExpectDownload(func() error { return nil }, 0); err != nil {
+		t.Fatalf("ExpectDownload: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "timeout")
+}
+
+func TestExpectDownloadTriggerError(t *testing.T) {
+	var cancelErr error
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/page/expect-download/cancel" {
+			return nil, cancelErr
+		}
+		return nil, nil
+	})
+
+	// trigger 失败时取消已开始的下载监听
+	triggerErr := errors.New("button not found")
+	if _, err := hc.ExpectDownload(func() error { return triggerErr }, time.Second); !errors.Is(err, triggerErr) {
+		t.Fatalf("ExpectDownload error = %v, want %v", err, triggerErr)
+	}
+	assertEndpoints(t, fb, "/api/page/expect-download/start", "/api/page/expect-download/cancel")
+	assertBody(t, fb.lastCall(t), "sessionId", "test-session")
+
+	cancelErr = errors.New("no pending download")
+	_, err := hc.ExpectDownload(func() error { return triggerErr }, time.Second)
+	if !errors.Is(err, triggerErr) || !strings.Contains(err.Error(), "no pending download") {
+		t.Errorf("ExpectDownload error = %v, want %v with the cancel error", err, triggerErr)
+	}
+}
+
+func TestSetDownloadPath(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	dir := t.TempDir()
+	if err := hc.SetDownloadPath(dir); err != nil {
+		t.Fatalf("SetDownloadPath: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "path", dir)
+
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(dir, "missing"), file} {
+		if err := hc.SetDownloadPath(path); err == nil {
+			t.Errorf("SetDownloadPath(%q): expected error", path)
+		}
+	}
+	if n := len(fb.Calls()); n != 1 {
+		t.Errorf("sent %d requests, want 1 (invalid paths must not reach the server)", n)
+	}
+}
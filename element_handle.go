@@ -0,0 +1,86 @@
+package cdpsdk
+
+import (
+	"fmt"
+)
+
+// ElementHandle 服务端解析后的元素引用，后续操作不再重新查询选择器
+// 使用完毕后应调用 Dispose 释放
+type ElementHandle struct {
+	client *HTTPClient
+	id     string
+}
+
+// Handle 解析元素并返回其引用
+func (l *Locator) Handle() (*ElementHandle, error) {
+	resp, err := l.client.doRequest("POST", "/api/element/handle", l.body())
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := resp.Data["handleId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("handleId not found in response")
+	}
+
+	return &ElementHandle{
+		client: l.client,
+		id:     id,
+	}, nil
+}
+
+// ID 获取元素引用 ID
+func (h *ElementHandle) ID() string {
+	return h.id
+}
+
+// body 构造元素引用请求体
+func (h *ElementHandle) body() map[string]any {
+	return map[string]any{
+		"sessionId": h.client.sessionId,
+		"handleId":  h.id,
+	}
+}
+
+// Click 点击元素
+func (h *ElementHandle) Click() error {
+	_, err := h.client.doRequest("POST", "/api/element-handle/click", h.body())
+	return err
+}
+
+// Text 获取元素文本
+func (h *ElementHandle) Text() (string, error) {
+	resp, err := h.client.doRequest("POST", "/api/element-handle/text", h.body())
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := resp.Data["text"].(string); ok {
+		return text, nil
+	}
+
+	return "", fmt.Errorf("text not found in response")
+}
+
+// Attribute 获取元素属性
+func (h *ElementHandle) Attribute(attr string) (string, error) {
+	body := h.body()
+	body["attribute"] = attr
+
+	resp, err := h.client.doRequest("POST", "/api/element-handle/attribute", body)
+	if err != nil {
+		return "", err
+	}
+
+	if value, ok := resp.Data["value"].(string); ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("value not found in response")
+}
+
+// Dispose 释放元素引用
+func (h *ElementHandle) Dispose() error {
+	_, err := h.client.doRequest("POST", "/api/element-handle/dispose", h.body())
+	return err
+}
@@ -0,0 +1,50 @@
+package cdpsdk
+
+import "testing"
+
+func TestElementHandle(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/element/handle":
+			return map[string]any{"handleId": "h1"}, nil
+		case "/api/element-handle/text":
+			return map[string]any{"text": "Apply"}, nil
+		case "/api/element-handle/attribute":
+			return map[string]any{"value": "/apply"}, nil
+		}
+		return nil, nil
+	})
+
+	handle, err := page.Locator(".job").Nth(3).Locator("a").Handle()
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if handle.ID() != "h1" {
+		t.Errorf("ID = %q", handle.ID())
+	}
+
+	if text, err := handle.Text(); err != nil || text != "Apply" {
+		t.Errorf("Text = %q, %v", text, err)
+	}
+	if href, err := handle.Attribute("href"); err != nil || href != "/apply" {
+		t.Errorf("Attribute = %q, %v", href, err)
+	}
+	if err := handle.Click(); err != nil {
+		t.Fatalf("Click: %v", err)
+	}
+	if err := handle.Dispose(); err != nil {
+		t.Fatalf("Dispose: %v", err)
+	}
+
+	assertEndpoints(t, fb,
+		"/api/element/handle",
+		"/api/element-handle/text",
+		"/api/element-handle/attribute",
+		"/api/element-handle/click",
+		"/api/element-handle/dispose",
+	)
+	for _, call := range fb.Calls()[1:] {
+		assertBody(t, call, "handleId", "h1")
+		assertNoBodyKey(t, call, "selector")
+	}
+}
@@ -0,0 +1,17 @@
+package cdpsdk
+
+import (
+	"errors"
+)
+
+// ErrElementNotRendered 元素未渲染（隐藏或尺寸为 0）
+var ErrElementNotRendered = errors.New("element is not rendered")
+
+// ErrTimeout 等待超时
+var ErrTimeout = errors.New("timeout")
+
+// ErrTooManyMatches 匹配元素数量超过 SetMaxMatches 设置的上限
+var ErrTooManyMatches = errors.New("too many matches")
+
+// ErrEventStreamClosed 事件流在等待的事件到达前被关闭
+var ErrEventStreamClosed = errors.New("event stream closed")
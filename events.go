@@ -0,0 +1,238 @@
+package cdpsdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Event 页面事件
+type Event struct {
+	Type   string         `json:"type"`
+	PageID string         `json:"pageId"`
+	Data   map[string]any `json:"data"`
+}
+
+// 事件缓冲区满时的处理策略
+const (
+	EventOverflowBlock = "block" // 暂停读取事件流，直到 handler 处理完缓冲区中的事件，不丢失事件
+	EventOverflowDrop  = "drop"  // 丢弃新到达的事件，读取不受 handler 速度影响
+)
+
+// defaultEventBufferSize 事件缓冲区的默认大小
+const defaultEventBufferSize = 256
+
+// StreamOptions 事件订阅选项
+type StreamOptions struct {
+	BufferSize int    // 等待 handler 处理的事件缓冲数量，0 表示使用默认值 256
+	Overflow   string // 缓冲区满时的策略：block（默认）或 drop
+
+	// OnClose 订阅结束（服务端关闭事件流、读取出错或调用 stop）后调用一次，在最后一次 handler 调用返回之后执行
+	// err 为读取事件流的错误，服务端正常关闭或调用 stop 时为 nil
+	OnClose func(err error)
+}
+
+// StreamEvents 通过 SSE 订阅页面事件（如 console、pageerror），使用默认的缓冲选项
+func (hc *HTTPClient) StreamEvents(events []string, handler func(Event)) (stop func(), err error) {
+	return hc.StreamEventsWithOptions(events, handler, StreamOptions{})
+}
+
+// StreamEventsWithOptions 通过 SSE 订阅页面事件，事件经缓冲区交给单独的协程按到达顺序逐个调用 handler，
+// 处理较慢的 handler 不会阻塞事件流的读取，直到缓冲区写满
+// 返回的 stop 用于取消订阅，可重复调用，也可在 handler 中调用；stop 不等待 handler 返回，
+// 调用 stop 时已从缓冲区取出的事件仍可能在 stop 返回后交给 handler，需要确认不再有 handler 调用时使用 StreamOptions.OnClose
+func (hc *HTTPClient) StreamEventsWithOptions(events []string, handler func(Event), opts StreamOptions) (stop func(), err error) {
+	switch opts.Overflow {
+	case "", EventOverflowBlock, EventOverflowDrop:
+	default:
+		return nil, fmt.Errorf("invalid event overflow policy %q", opts.Overflow)
+	}
+
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultEventBufferSize
+	}
+
+	query := url.Values{}
+	query.Set("sessionId", hc.sessionId)
+	query.Set("events", strings.Join(events, ","))
+
+	treq, err := hc.newTransportRequest("GET", "/api/page/events?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	treq.Header.Set("Accept", "text/event-stream")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := hc.transport.Stream(ctx, treq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	queue := make(chan Event, bufferSize)
+	var readErr error
+	go func() {
+		defer close(queue)
+		defer stream.Close()
+		readErr = readEvents(ctx, stream, func(event Event) {
+			if opts.Overflow == EventOverflowDrop {
+				select {
+				case queue <- event:
+				default:
+				}
+				return
+			}
+
+			select {
+			case queue <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	go func() {
+		for event := range queue {
+			// 取消后继续读取 queue 直到读取协程退出，但不再调用 handler
+			if ctx.Err() != nil {
+				continue
+			}
+			handler(event)
+		}
+
+		if opts.OnClose != nil {
+			// 调用 stop 导致的读取错误不视为错误
+			if ctx.Err() != nil {
+				readErr = nil
+			}
+			opts.OnClose(readErr)
+		}
+	}()
+
+	return func() {
+		cancel()
+	}, nil
+}
+
+// WaitForEvent 订阅 eventType 事件并阻塞，直到收到第一个事件或 ctx 结束，返回后自动取消订阅
+// 收到事件前事件流被关闭时返回 ErrEventStreamClosed
+func (hc *HTTPClient) WaitForEvent(ctx context.Context, eventType string) (*Event, error) {
+	received := make(chan Event, 1)
+	closed := make(chan error, 1)
+	stop, err := hc.StreamEventsWithOptions([]string{eventType}, func(event Event) {
+		if event.Type != eventType {
+			return
+		}
+		select {
+		case received <- event:
+		default:
+		}
+	}, StreamOptions{
+		OnClose: func(err error) {
+			closed <- err
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	select {
+	case event := <-received:
+		return &event, nil
+	case err := <-closed:
+		// OnClose 在最后一次 handler 调用之后执行，关闭前收到的事件仍然有效
+		select {
+		case event := <-received:
+			return &event, nil
+		default:
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEventStreamClosed, err)
+		}
+		return nil, ErrEventStreamClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// readEvents 读取 SSE 数据流，每个事件的 data 字段解码为 Event，返回读取错误，数据流正常结束时返回 nil
+func readEvents(ctx context.Context, r io.Reader, handler func(Event)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if data.Len() > 0 && ctx.Err() == nil {
+				var event Event
+				if err := json.Unmarshal([]byte(data.String()), &event); err == nil {
+					handler(event)
+				}
+			}
+			data.Reset()
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// ConsoleMessage 页面 console 输出
+type ConsoleMessage struct {
+	Type     string // log、info、warning、error、debug 等
+	Text     string
+	Location ConsoleLocation
+}
+
+// ConsoleLocation console 调用所在的源码位置
+type ConsoleLocation struct {
+	URL          string
+	LineNumber   int
+	ColumnNumber int
+}
+
+// parseConsoleMessage 从 console 事件数据中解析 ConsoleMessage
+func parseConsoleMessage(data map[string]any) ConsoleMessage {
+	var msg ConsoleMessage
+	msg.Type, _ = data["type"].(string)
+	msg.Text, _ = data["text"].(string)
+	if location, ok := data["location"].(map[string]any); ok {
+		msg.Location.URL, _ = location["url"].(string)
+		if line, ok := location["lineNumber"].(float64); ok {
+			msg.Location.LineNumber = int(line)
+		}
+		if column, ok := location["columnNumber"].(float64); ok {
+			msg.Location.ColumnNumber = int(column)
+		}
+	}
+	return msg
+}
+
+// OnConsole 订阅页面 console 输出，返回的 stop 用于取消订阅
+func (p *Page) OnConsole(handler func(ConsoleMessage)) (stop func(), err error) {
+	return p.StreamEvents([]string{"console"}, func(event Event) {
+		if event.Type == "console" {
+			handler(parseConsoleMessage(event.Data))
+		}
+	})
+}
+
+// WaitForEvent 等待下一个 eventType 事件，ctx 结束时返回其错误，事件流关闭时返回 ErrEventStreamClosed
+func (p *Page) WaitForEvent(ctx context.Context, eventType string) (*Event, error) {
+	return p.client.WaitForEvent(ctx, eventType)
+}
@@ -0,0 +1,430 @@
+package cdpsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sseEvent 将事件编码为 SSE 消息
+func sseEvent(event Event) string {
+	data, err := json.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+	return "data: " + string(data) + "\n\n"
+}
+
+// eventRecorder 并发安全地记录 handler 收到的事件
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) add(event Event) {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+}
+
+func (r *eventRecorder) get() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// waitFor 等待收到 n 个事件
+func (r *eventRecorder) waitFor(t *testing.T, n int) []Event {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if events := r.get(); len(events) >= n {
+			return events
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("received %d events, want %d", len(r.get()), n)
+	return nil
+}
+
+func TestStreamEventsHTTP(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, sseEvent(Event{Type: "console", PageID: "p1", Data: map[string]any{"text": "first"}}))
+		io.WriteString(w, ": keep-alive\n\n")
+		io.WriteString(w, sseEvent(Event{Type: "pageerror", PageID: "p1", Data: map[string]any{"message": "second"}}))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var logged []string
+	hc := NewHTTPClient(server.URL, WithSessionID("s1"), WithTimeout(50*time.Millisecond))
+	hc.SetLogger(func(method, endpoint string, reqBody, respBody []byte, status int, duration time.Duration) {
+		logged = append(logged, fmt.Sprintf("%s %d", method, status))
+	})
+
+	var recorder eventRecorder
+	stop, err := hc.StreamEvents([]string{"console", "pageerror"}, recorder.add)
+	if err != nil {
+		t.Fatalf("StreamEvents: %v", err)
+	}
+	defer stop()
+
+	// 客户端请求超时不能中断长连接
+	time.Sleep(100 * time.Millisecond)
+
+	events := recorder.waitFor(t, 2)
+	if events[0].Type != "console" || events[0].Data["text"] != "first" {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if events[1].Type != "pageerror" || events[1].Data["message"] != "second" {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+
+	r := <-requests
+	if r.URL.Path != "/api/page/events" || r.URL.Query().Get("sessionId") != "s1" || r.URL.Query().Get("events") != "console,pageerror" {
+		t.Errorf("request = %s", r.URL)
+	}
+	if got := r.Header.Get("Accept"); got != "text/event-stream" {
+		t.Errorf("Accept = %q", got)
+	}
+	if !reflect.DeepEqual(logged, []string{"GET 200"}) {
+		t.Errorf("logged = %v, want one entry for the stream", logged)
+	}
+}
+
+func TestStreamEventsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "session not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL)
+	if _, err := hc.StreamEvents([]string{"console"}, func(Event) {}); err == nil {
+		t.Fatal("StreamEvents: expected error")
+	}
+}
+
+// newEventServer 创建推送事件流的假服务端，发送到返回通道的 SSE 消息依次写给客户端
+func newEventServer(t *testing.T) (*HTTPClient, chan<- string) {
+	t.Helper()
+	messages := make(chan string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case msg := <-messages:
+				io.WriteString(w, msg)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewHTTPClient(server.URL, WithSessionID("test-session")), messages
+}
+
+func TestWaitForEventStreamClosed(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+	pw := newPipeStream(ft)
+
+	go func() {
+		io.WriteString(pw, sseEvent(Event{Type: "console"}))
+		pw.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := hc.WaitForEvent(ctx, "load"); !errors.Is(err, ErrEventStreamClosed) {
+		t.Errorf("WaitForEvent error = %v, want ErrEventStreamClosed", err)
+	}
+}
+
+func TestOnConsole(t *testing.T) {
+	hc, messages := newEventServer(t)
+	page := NewPage(hc)
+
+	received := make(chan ConsoleMessage, 1)
+	stop, err := page.OnConsole(func(msg ConsoleMessage) {
+		received <- msg
+	})
+	if err != nil {
+		t.Fatalf("OnConsole: %v", err)
+	}
+	defer stop()
+
+	messages <- sseEvent(Event{Type: "console", Data: map[string]any{
+		"type": "error",
+		"text": "Failed to load resource",
+		"location": map[string]any{
+			"url":          "https://example.com/app.js",
+			"lineNumber":   12,
+			"columnNumber": 7,
+		},
+	}})
+
+	select {
+	case msg := <-received:
+		want := ConsoleMessage{
+			Type:     "error",
+			Text:     "Failed to load resource",
+			Location: ConsoleLocation{URL: "https://example.com/app.js", LineNumber: 12, ColumnNumber: 7},
+		}
+		if msg != want {
+			t.Errorf("message = %+v, want %+v", msg, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no console message received")
+	}
+}
+
+func TestStreamEventsOnClose(t *testing.T) {
+	tests := []struct {
+		name    string
+		close   func(pw *io.PipeWriter, stop func())
+		wantErr bool
+	}{
+		{name: "server closed", close: func(pw *io.PipeWriter, stop func()) { pw.Close() }},
+		{name: "read error", close: func(pw *io.PipeWriter, stop func()) { pw.CloseWithError(errors.New("connection reset")) }, wantErr: true},
+		{name: "stopped", close: func(pw *io.PipeWriter, stop func()) { stop() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc, ft := newFakeTransportClient(nil)
+			pw := newPipeStream(ft)
+
+			var recorder eventRecorder
+			closed := make(chan error, 1)
+			stop, err := hc.StreamEventsWithOptions([]string{"console"}, recorder.add, StreamOptions{
+				OnClose: func(err error) {
+					// OnClose 在最后一次 handler 调用之后执行
+					if n := len(recorder.get()); n != 1 {
+						t.Errorf("OnClose called after %d events, want 1", n)
+					}
+					closed <- err
+				},
+			})
+			if err != nil {
+				t.Fatalf("StreamEventsWithOptions: %v", err)
+			}
+			defer stop()
+
+			io.WriteString(pw, sseEvent(Event{Type: "console"}))
+			recorder.waitFor(t, 1)
+			tt.close(pw, stop)
+
+			select {
+			case err := <-closed:
+				if (err != nil) != tt.wantErr {
+					t.Errorf("OnClose error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("OnClose was not called")
+			}
+		})
+	}
+}
+
+func TestWaitForEvent(t *testing.T) {
+	hc, messages := newEventServer(t)
+	page := NewPage(hc)
+
+	go func() {
+		messages <- sseEvent(Event{Type: "console"})
+		messages <- sseEvent(Event{Type: "load", Data: map[string]any{"url": "https://example.com"}})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	event, err := page.WaitForEvent(ctx, "load")
+	if err != nil {
+		t.Fatalf("WaitForEvent: %v", err)
+	}
+	if event.Type != "load" || event.Data["url"] != "https://example.com" {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestWaitForEventContextDone(t *testing.T) {
+	hc, _ := newEventServer(t)
+	page := NewPage(hc)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := page.WaitForEvent(ctx, "load"); err != context.DeadlineExceeded {
+		t.Errorf("WaitForEvent error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// newPipeStream 创建由测试写入的事件流，与真实传输层一样在 ctx 取消后结束读取
+func newPipeStream(ft *fakeTransport) *io.PipeWriter {
+	pr, pw := io.Pipe()
+	ft.stream = func(ctx context.Context, call fakeCall) (io.ReadCloser, error) {
+		go func() {
+			<-ctx.Done()
+			pr.CloseWithError(ctx.Err())
+		}()
+		return pr, nil
+	}
+	return pw
+}
+
+func TestStreamEventsThroughTransport(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+	pw := newPipeStream(ft)
+
+	var recorder eventRecorder
+	stop, err := hc.StreamEvents([]string{"console"}, recorder.add)
+	if err != nil {
+		t.Fatalf("StreamEvents: %v", err)
+	}
+	defer stop()
+
+	for i := 0; i < 3; i++ {
+		io.WriteString(pw, sseEvent(Event{Type: "console", Data: map[string]any{"n": i}}))
+	}
+
+	events := recorder.waitFor(t, 3)
+	for i, event := range events {
+		if event.Data["n"] != float64(i) {
+			t.Errorf("events[%d] = %+v, want events in order", i, event)
+		}
+	}
+
+	call := ft.lastCall(t)
+	if call.Method != "GET" || call.Endpoint != "/api/page/events" || call.Query.Get("events") != "console" {
+		t.Errorf("request = %s %s %v", call.Method, call.Endpoint, call.Query)
+	}
+}
+
+func TestStreamEventsStopInsideHandler(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+	pw := newPipeStream(ft)
+
+	var recorder eventRecorder
+	var stop func()
+	stopped := make(chan struct{})
+	stop, err := hc.StreamEvents([]string{"console"}, func(event Event) {
+		recorder.add(event)
+		stop()
+		close(stopped)
+	})
+	if err != nil {
+		t.Fatalf("StreamEvents: %v", err)
+	}
+
+	io.WriteString(pw, sseEvent(Event{Type: "console"}))
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stop called from the handler did not return")
+	}
+
+	// 取消后写入的事件不会再交给 handler
+	io.WriteString(pw, sseEvent(Event{Type: "console"}))
+	time.Sleep(20 * time.Millisecond)
+	if n := len(recorder.get()); n != 1 {
+		t.Errorf("handler called %d times, want 1", n)
+	}
+	stop()
+}
+
+func TestStreamEventsOverflowBlock(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+	pw := newPipeStream(ft)
+
+	var recorder eventRecorder
+	release := make(chan struct{})
+	stop, err := hc.StreamEventsWithOptions([]string{"console"}, func(event Event) {
+		<-release
+		recorder.add(event)
+	}, StreamOptions{BufferSize: 1, Overflow: EventOverflowBlock})
+	if err != nil {
+		t.Fatalf("StreamEventsWithOptions: %v", err)
+	}
+	defer stop()
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			io.WriteString(pw, sseEvent(Event{Type: "console", Data: map[string]any{"n": i}}))
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	events := recorder.waitFor(t, 5)
+	for i, event := range events {
+		if event.Data["n"] != float64(i) {
+			t.Errorf("events[%d] = %+v, want all events in order", i, event)
+		}
+	}
+}
+
+func TestStreamEventsOverflowDrop(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+	pw := newPipeStream(ft)
+
+	var recorder eventRecorder
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	stop, err := hc.StreamEventsWithOptions([]string{"console"}, func(event Event) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		recorder.add(event)
+	}, StreamOptions{BufferSize: 1, Overflow: EventOverflowDrop})
+	if err != nil {
+		t.Fatalf("StreamEventsWithOptions: %v", err)
+	}
+	defer stop()
+
+	io.WriteString(pw, sseEvent(Event{Type: "console", Data: map[string]any{"n": 0}}))
+	<-started
+
+	// handler 阻塞时，第一个事件进入缓冲区，之后的事件被丢弃，读取不会被阻塞
+	for i := 1; i < 5; i++ {
+		io.WriteString(pw, sseEvent(Event{Type: "console", Data: map[string]any{"n": i}}))
+	}
+	close(release)
+
+	events := recorder.waitFor(t, 2)
+	time.Sleep(20 * time.Millisecond)
+	if events = recorder.get(); len(events) != 2 {
+		t.Fatalf("received %d events, want 2", len(events))
+	}
+	if events[0].Data["n"] != float64(0) || events[1].Data["n"] != float64(1) {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestStreamEventsInvalidOverflow(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+
+	_, err := hc.StreamEventsWithOptions([]string{"console"}, func(Event) {}, StreamOptions{Overflow: "discard"})
+	if err == nil {
+		t.Fatal("StreamEventsWithOptions: expected error for invalid overflow policy")
+	}
+	if n := len(ft.Calls()); n != 0 {
+		t.Errorf("sent %d requests", n)
+	}
+}
@@ -11,7 +11,7 @@ import (
 func main() {
 	// 创建 HTTP 客户端
 	sessionId := "http-practical-example-session"
-	client := cdpsdk.NewHTTPClient("http://localhost:3000", sessionId)
+	client := cdpsdk.NewHTTPClient("http://localhost:3000", cdpsdk.WithSessionID(sessionId))
 
 	// 启动浏览器
 	fmt.Println("🚀 开始自动化流程...")
@@ -105,13 +105,10 @@ func main() {
 
 	// 截图保存当前状态
 	fmt.Println("\n📌 步骤 12: 截图...")
-	screenshotData, err := page.Screenshot("png")
-	if err != nil {
+	if err := page.ScreenshotToFile("screenshot.png", ""); err != nil {
 		log.Printf("❌ 截图失败: %v\n", err)
 	} else {
-		fmt.Printf("✅ 截图成功（数据大小: %d 字节）\n", len(screenshotData))
-		// 可以将 screenshotData 保存到文件
-		// err := os.WriteFile("screenshot.png", screenshotData, 0644)
+		fmt.Println("✅ 截图已保存到 screenshot.png")
 	}
 
 	// 获取页面 HTML（可选）
@@ -10,7 +10,7 @@ import (
 func main() {
 	// 创建 HTTP 客户端
 	sessionId := "locator-example-session"
-	client := cdpsdk.NewHTTPClient("http://localhost:3000", sessionId)
+	client := cdpsdk.NewHTTPClient("http://localhost:3000", cdpsdk.WithSessionID(sessionId))
 
 	// 启动浏览器
 	fmt.Println("🚀 测试 Locator 功能...")
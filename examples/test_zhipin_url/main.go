@@ -11,7 +11,7 @@ import (
 func main() {
 	// 创建 HTTP 客户端
 	sessionId := "test-zhipin-url-session"
-	client := cdpsdk.NewHTTPClient("http://localhost:3000", sessionId)
+	client := cdpsdk.NewHTTPClient("http://localhost:3000", cdpsdk.WithSessionID(sessionId))
 
 	// 目标 URL
 	targetURL := "https://www.zhipin.com/gongsi/job/5d627415a46b4a750nJ9.html?ka=company-jobs"
@@ -78,19 +78,9 @@ func main() {
 		"[class*='job-name']",
 	}
 
-	for _, selector := range jobTitleSelectors {
-		locator := page.Locator(selector)
-		exists, err := locator.Exists()
-		if err == nil && exists {
-			fmt.Printf("✅ 找到职位标题元素: %s\n", selector)
-			// 尝试获取文本
-			locator := page.Locator(selector)
-			text, err := locator.Text()
-			if err == nil {
-				fmt.Printf("   职位标题: %s\n", text)
-			}
-			break
-		}
+	if text, err := page.LocatorAny(jobTitleSelectors...).Text(); err == nil {
+		fmt.Println("✅ 找到职位标题元素")
+		fmt.Printf("   职位标题: %s\n", text)
 	}
 
 	// 8. 检查公司名称元素
@@ -102,19 +92,9 @@ func main() {
 		"[class*='company-name']",
 	}
 
-	for _, selector := range companySelectors {
-		locator := page.Locator(selector)
-		exists, err := locator.Exists()
-		if err == nil && exists {
-			fmt.Printf("✅ 找到公司名称元素: %s\n", selector)
-			// 尝试获取文本
-			locator := page.Locator(selector)
-			text, err := locator.Text()
-			if err == nil {
-				fmt.Printf("   公司名称: %s\n", text)
-			}
-			break
-		}
+	if text, err := page.LocatorAny(companySelectors...).Text(); err == nil {
+		fmt.Println("✅ 找到公司名称元素")
+		fmt.Printf("   公司名称: %s\n", text)
 	}
 
 	// 9. 检查薪资元素
@@ -126,19 +106,9 @@ func main() {
 		"[class*='salary']",
 	}
 
-	for _, selector := range salarySelectors {
-		locator := page.Locator(selector)
-		exists, err := locator.Exists()
-		if err == nil && exists {
-			fmt.Printf("✅ 找到薪资元素: %s\n", selector)
-			// 尝试获取文本
-			locator := page.Locator(selector)
-			text, err := locator.Text()
-			if err == nil {
-				fmt.Printf("   薪资: %s\n", text)
-			}
-			break
-		}
+	if text, err := page.LocatorAny(salarySelectors...).Text(); err == nil {
+		fmt.Println("✅ 找到薪资元素")
+		fmt.Printf("   薪资: %s\n", text)
 	}
 
 	// 10. 获取页面 HTML（前 500 字符）
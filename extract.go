@@ -0,0 +1,146 @@
+package cdpsdk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// extractTag 解析后的 cdp 结构体标签
+type extractTag struct {
+	selector string
+	attr     string // text 表示元素文本，其余为属性名
+}
+
+// parseExtractTag 解析形如 `cdp:"selector=.job-name,attr=href"` 的标签，attr 默认为 text
+// 选择器中可以包含逗号，如 `cdp:"selector=h1, h2"`
+func parseExtractTag(tag string) extractTag {
+	parsed := extractTag{attr: "text"}
+	var current *string
+	for _, part := range strings.Split(tag, ",") {
+		switch {
+		case strings.HasPrefix(part, "selector="):
+			parsed.selector = strings.TrimPrefix(part, "selector=")
+			current = &parsed.selector
+		case strings.HasPrefix(part, "attr="):
+			parsed.attr = strings.TrimPrefix(part, "attr=")
+			current = &parsed.attr
+		case current != nil:
+			*current += "," + part
+		}
+	}
+	return parsed
+}
+
+// Extract 按结构体字段的 cdp 标签抽取页面数据到 v（结构体指针）
+//
+// 支持的字段类型：
+//   - string：selector 匹配元素的文本或 attr 指定的属性
+//   - []string：所有匹配元素的文本或属性
+//   - 结构体：以 selector 匹配的元素为范围继续抽取，未设置 selector 时沿用当前范围
+//   - []结构体：selector 为容器选择器，每个匹配的容器抽取为一个元素
+//
+// 单个字段失败不会中断抽取，其余字段仍会填充，所有错误合并后返回
+func (p *Page) Extract(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("extract target must be a pointer to struct, got %T", v)
+	}
+
+	return p.extractStruct(rv.Elem(), nil)
+}
+
+// locate 在 scope 范围内定位 selector，scope 为 nil 时在整个页面中定位
+func (p *Page) locate(scope *Locator, selector string) *Locator {
+	if scope == nil {
+		return p.Locator(selector)
+	}
+	return scope.Locator(selector)
+}
+
+func (p *Page) extractStruct(rv reflect.Value, scope *Locator) error {
+	var errs []error
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("cdp")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		if err := p.extractField(rv.Field(i), parseExtractTag(tag), scope); err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", field.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Page) extractField(fv reflect.Value, tag extractTag, scope *Locator) error {
+	switch {
+	case fv.Kind() == reflect.String:
+		if tag.selector == "" {
+			return fmt.Errorf("missing selector")
+		}
+		value, err := extractValue(p.locate(scope, tag.selector), tag.attr)
+		if err != nil {
+			return err
+		}
+		fv.SetString(value)
+
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		if tag.selector == "" {
+			return fmt.Errorf("missing selector")
+		}
+		locator := p.locate(scope, tag.selector)
+		var values []string
+		var err error
+		if tag.attr == "text" {
+			values, err = locator.AllTexts()
+		} else {
+			values, err = locator.AllAttributes(tag.attr)
+		}
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(values).Convert(fv.Type()))
+
+	case fv.Kind() == reflect.Struct:
+		if tag.selector == "" {
+			return p.extractStruct(fv, scope)
+		}
+		return p.extractStruct(fv, p.locate(scope, tag.selector))
+
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Struct:
+		if tag.selector == "" {
+			return fmt.Errorf("missing container selector")
+		}
+		containers, err := p.locate(scope, tag.selector).All()
+		if err != nil {
+			return err
+		}
+
+		slice := reflect.MakeSlice(fv.Type(), len(containers), len(containers))
+		var errs []error
+		for i, container := range containers {
+			if err := p.extractStruct(slice.Index(i), container); err != nil {
+				errs = append(errs, fmt.Errorf("item %d: %w", i, err))
+			}
+		}
+		fv.Set(slice)
+		return errors.Join(errs...)
+
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
+
+// extractValue 获取元素文本或属性
+func extractValue(locator *Locator, attr string) (string, error) {
+	if attr == "text" {
+		return locator.Text()
+	}
+	return locator.Attribute(attr)
+}
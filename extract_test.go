@@ -0,0 +1,97 @@
+package cdpsdk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type extractJob struct {
+	Title string `cdp:"selector=.title"`
+	Link  string `cdp:"selector=a,attr=href"`
+}
+
+type extractListing struct {
+	Heading string       `cdp:"selector=h1, h2"`
+	Tags    []string     `cdp:"selector=.tag"`
+	Jobs    []extractJob `cdp:"selector=.job"`
+	ignored string
+}
+
+// extractBackend 模拟服务端：带 parent 的请求返回 "选择器@容器序号"
+func extractBackend(call fakeCall) (map[string]any, error) {
+	selector, _ := call.Body["selector"].(string)
+	value := selector
+	if parent, ok := call.Body["parent"].(map[string]any); ok {
+		value = fmt.Sprintf("%s@%v", selector, parent["nth"])
+	}
+
+	switch call.Endpoint {
+	case "/api/element/count":
+		return map[string]any{"count": 2}, nil
+	case "/api/element/all-texts":
+		return map[string]any{"texts": []any{"go", "remote"}}, nil
+	case "/api/element/attribute":
+		return map[string]any{"value": value + "#" + call.Body["attribute"].(string)}, nil
+	case "/api/element/text":
+		return map[string]any{"text": value}, nil
+	}
+	return nil, fmt.Errorf("unexpected endpoint %s", call.Endpoint)
+}
+
+func TestExtract(t *testing.T) {
+	page, _ := newFakePage(t, extractBackend)
+
+	var listing extractListing
+	if err := page.Extract(&listing); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := extractListing{
+		Heading: "h1, h2",
+		Tags:    []string{"go", "remote"},
+		Jobs: []extractJob{
+			{Title: ".title@0", Link: "a@0#href"},
+			{Title: ".title@1", Link: "a@1#href"},
+		},
+	}
+	if !reflect.DeepEqual(listing, want) {
+		t.Errorf("listing = %+v, want %+v", listing, want)
+	}
+}
+
+func TestExtractPartialFailure(t *testing.T) {
+	page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		if call.Body["selector"] == ".tag" {
+			return nil, errors.New("timeout")
+		}
+		return extractBackend(call)
+	})
+
+	var listing extractListing
+	err := page.Extract(&listing)
+	if err == nil || !strings.Contains(err.Error(), "field Tags") {
+		t.Fatalf("Extract error = %v, want error for Tags", err)
+	}
+	if listing.Heading != "h1, h2" || len(listing.Jobs) != 2 {
+		t.Errorf("other fields were not filled: %+v", listing)
+	}
+}
+
+func TestExtractInvalidTarget(t *testing.T) {
+	page, _ := newFakePage(t, nil)
+
+	var listing extractListing
+	if err := page.Extract(listing); err == nil {
+		t.Error("Extract: expected error for non-pointer target")
+	}
+
+	var unsupported struct {
+		Count int `cdp:"selector=.count"`
+	}
+	if err := page.Extract(&unsupported); err == nil {
+		t.Error("Extract: expected error for unsupported field type")
+	}
+}
@@ -0,0 +1,160 @@
+package cdpsdk
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// fakeCall 假服务端收到的一次请求
+type fakeCall struct {
+	Method   string
+	Endpoint string // 不含查询参数
+	Query    url.Values
+	Header   http.Header
+	Body     map[string]any
+}
+
+// fakeBackend 基于 httptest 的假服务端，记录所有请求，并按 handler 返回响应
+type fakeBackend struct {
+	mu    sync.Mutex
+	calls []fakeCall
+
+	handler func(call fakeCall) (map[string]any, error) // 返回 data，error 作为服务端错误（success=false）返回
+	binary  func(call fakeCall) ([]byte, error)         // 返回非 nil 数据时作为二进制响应体，返回 error 时响应 500
+}
+
+func (b *fakeBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := fakeCall{
+		Method:   r.Method,
+		Endpoint: r.URL.Path,
+		Query:    r.URL.Query(),
+		Header:   r.Header.Clone(),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&call.Body); err != nil && err != io.EOF {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b.mu.Lock()
+	b.calls = append(b.calls, call)
+	b.mu.Unlock()
+
+	if b.binary != nil {
+		data, err := b.binary(call)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if data != nil {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(data)
+			return
+		}
+	}
+
+	var data map[string]any
+	if b.handler != nil {
+		var err error
+		if data, err = b.handler(call); err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(HTTPResponse{Success: false, Error: err.Error()})
+			return
+		}
+	}
+	writeJSON(w, data)
+}
+
+// Calls 返回已记录的请求
+func (b *fakeBackend) Calls() []fakeCall {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]fakeCall(nil), b.calls...)
+}
+
+// Endpoints 返回已记录请求的端点，按发送顺序排列
+func (b *fakeBackend) Endpoints() []string {
+	var endpoints []string
+	for _, call := range b.Calls() {
+		endpoints = append(endpoints, call.Endpoint)
+	}
+	return endpoints
+}
+
+// lastCall 返回最后一次请求，没有请求时测试失败
+func (b *fakeBackend) lastCall(t *testing.T) fakeCall {
+	t.Helper()
+	calls := b.Calls()
+	if len(calls) == 0 {
+		t.Fatal("no request was sent")
+	}
+	return calls[len(calls)-1]
+}
+
+// newFakeClient 创建连接到假服务端的客户端，handler 为 nil 时所有请求返回空数据
+func newFakeClient(t *testing.T, handler func(call fakeCall) (map[string]any, error), opts ...Option) (*HTTPClient, *fakeBackend) {
+	t.Helper()
+	fb := &fakeBackend{handler: handler}
+	server := httptest.NewServer(fb)
+	t.Cleanup(server.Close)
+	return NewHTTPClient(server.URL, append([]Option{WithSessionID("test-session")}, opts...)...), fb
+}
+
+// newFakePage 创建连接到假服务端的页面
+func newFakePage(t *testing.T, handler func(call fakeCall) (map[string]any, error)) (*Page, *fakeBackend) {
+	t.Helper()
+	hc, fb := newFakeClient(t, handler)
+	return NewPage(hc), fb
+}
+
+// jsonValue 将 v 经过一次 JSON 编解码，便于与请求体中解码出的值比较
+func jsonValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// assertBody 断言请求体中 key 的值等于 want
+func assertBody(t *testing.T, call fakeCall, key string, want any) {
+	t.Helper()
+	got, ok := call.Body[key]
+	if !ok {
+		t.Fatalf("%s: body has no %q: %v", call.Endpoint, key, call.Body)
+	}
+	if !reflect.DeepEqual(got, jsonValue(want)) {
+		t.Errorf("%s: body[%q] = %#v, want %#v", call.Endpoint, key, got, jsonValue(want))
+	}
+}
+
+// assertNoBodyKey 断言请求体中不包含 key
+func assertNoBodyKey(t *testing.T, call fakeCall, key string) {
+	t.Helper()
+	if v, ok := call.Body[key]; ok {
+		t.Errorf("%s: body[%q] = %#v, want absent", call.Endpoint, key, v)
+	}
+}
+
+// assertEndpoints 断言请求按顺序发送到 want
+func assertEndpoints(t *testing.T, fb *fakeBackend, want ...string) {
+	t.Helper()
+	if got := fb.Endpoints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("endpoints = %v, want %v", got, want)
+	}
+}
+
+// writeJSON 以服务端格式返回成功响应
+func writeJSON(w http.ResponseWriter, data map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(HTTPResponse{Success: true, Data: data})
+}
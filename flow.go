@@ -0,0 +1,80 @@
+package cdpsdk
+
+import (
+	"fmt"
+)
+
+// flowStep 流程中的一个步骤
+type flowStep struct {
+	name string
+	fn   func(*Page) error
+}
+
+// Flow 页面操作流程，链式添加步骤，Run 时按顺序执行并在第一个错误处停止
+type Flow struct {
+	page  *Page
+	steps []flowStep
+}
+
+// NewFlow 创建页面操作流程
+func NewFlow(page *Page) *Flow {
+	return &Flow{
+		page: page,
+	}
+}
+
+// Flow 创建页面操作流程
+func (p *Page) Flow() *Flow {
+	return NewFlow(p)
+}
+
+// Then 添加自定义步骤
+func (f *Flow) Then(name string, fn func(*Page) error) *Flow {
+	f.steps = append(f.steps, flowStep{name: name, fn: fn})
+	return f
+}
+
+// Navigate 添加导航步骤
+func (f *Flow) Navigate(url string) *Flow {
+	return f.Then("navigate "+url, func(p *Page) error {
+		return p.Navigate(url)
+	})
+}
+
+// WaitForLoad 添加等待页面加载完成步骤
+func (f *Flow) WaitForLoad() *Flow {
+	return f.Then("wait for load", func(p *Page) error {
+		return p.WaitForLoadStateLoad()
+	})
+}
+
+// Wait 添加等待元素步骤
+func (f *Flow) Wait(selector string) *Flow {
+	return f.Then("wait "+selector, func(p *Page) error {
+		return p.Wait(selector)
+	})
+}
+
+// Click 添加点击步骤
+func (f *Flow) Click(selector string) *Flow {
+	return f.Then("click "+selector, func(p *Page) error {
+		return p.Click(selector)
+	})
+}
+
+// Type 添加输入步骤
+func (f *Flow) Type(selector, text string) *Flow {
+	return f.Then("type "+selector, func(p *Page) error {
+		return p.SetValue(selector, text)
+	})
+}
+
+// Run 按顺序执行所有步骤，返回第一个失败步骤的错误，后续步骤不再执行
+func (f *Flow) Run() error {
+	for i, step := range f.steps {
+		if err := step.fn(f.page); err != nil {
+			return fmt.Errorf("flow step %d (%s): %w", i+1, step.name, err)
+		}
+	}
+	return nil
+}
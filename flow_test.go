@@ -0,0 +1,56 @@
+package cdpsdk
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFlow(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	err := page.Flow().
+		Navigate("https://example.com/login").
+		Wait("#username").
+		Type("#username", "admin").
+		Click("#submit").
+		Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	assertEndpoints(t, fb,
+		"/api/page/navigate",
+		"/api/element/wait",
+		"/api/element/setValue",
+		"/api/element/click",
+	)
+}
+
+func TestFlowStopsOnFailure(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/element/click" {
+			return nil, errors.New("element is not visible")
+		}
+		return nil, nil
+	})
+
+	var ranAfter bool
+	err := NewFlow(page).
+		Navigate("https://example.com").
+		Click("#submit").
+		Then("check result", func(p *Page) error {
+			ranAfter = true
+			return nil
+		}).
+		Run()
+	if err == nil {
+		t.Fatal("Run: expected error")
+	}
+	if !strings.Contains(err.Error(), "flow step 2 (click #submit)") {
+		t.Errorf("error = %q, want it to name the failed step", err)
+	}
+	if ranAfter {
+		t.Error("steps after the failure were run")
+	}
+	assertEndpoints(t, fb, "/api/page/navigate", "/api/element/click")
+}
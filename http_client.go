@@ -1,19 +1,41 @@
 package cdpsdk
 
 import (
-	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
+	"mime"
 	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 )
 
 // HTTPClient HTTP 客户端
+// 每次调用都是独立的 HTTP 请求和响应，可被多个 goroutine 并发使用；
+// StartBrowser、Connect 会更新 sessionId，应在并发调用之前完成
 type HTTPClient struct {
 	baseURL    string
 	sessionId  string
 	httpClient *http.Client
+	customHTTP bool           // httpClient 由调用方通过 WithHTTPClient 提供
+	timeout    *time.Duration // WithTimeout 设置的请求超时，所有选项处理完后再应用
+	transport  Transport
+	retries    int               // 网络错误时的重试次数
+	headers    map[string]string // 每个请求都携带的请求头
+	autoScheme bool              // 导航时为缺少协议的 URL 自动补全 https://
+	navRetries int               // 导航遇到临时网络错误时的重试次数
+	navBackoff time.Duration     // 导航重试间隔
+	err        error             // 创建时的配置错误
+
+	maxMatches      int  // 批量获取时允许的最大匹配数量，0 表示不限制
+	truncateMatches bool // 超过 maxMatches 时截断而不是返回错误
+
+	screenshotFormat string // 截图未指定格式时使用的默认格式
 }
 
 // HTTPResponse HTTP 响应
@@ -23,98 +45,229 @@ type HTTPResponse struct {
 	Error   string         `json:"error,omitempty"`
 }
 
+// Option HTTP 客户端选项
+type Option func(*HTTPClient)
+
+// WithSessionID 设置会话 ID
+func WithSessionID(sessionId string) Option {
+	return func(hc *HTTPClient) {
+		hc.sessionId = sessionId
+	}
+}
+
+// WithTimeout 设置单次请求超时时间，默认 5 分钟
+// 与 WithHTTPClient 同时使用时不区分顺序，超时作用于自定义客户端的副本，不会修改调用方的 http.Client
+func WithTimeout(timeout time.Duration) Option {
+	return func(hc *HTTPClient) {
+		hc.timeout = &timeout
+	}
+}
+
+// WithHTTPClient 使用自定义的 http.Client
+func WithHTTPClient(client *http.Client) Option {
+	return func(hc *HTTPClient) {
+		hc.httpClient = client
+		hc.customHTTP = true
+	}
+}
+
+// WithRetries 设置请求发送失败（网络错误）时的重试次数，默认不重试
+// POST 请求只在未完整发出（如连接失败）时重试，已发出后超时或断开不会重试，避免重复点击、导航等操作
+func WithRetries(retries int) Option {
+	return func(hc *HTTPClient) {
+		hc.retries = retries
+	}
+}
+
+// WithDefaultHeaders 设置每个请求都携带的请求头
+func WithDefaultHeaders(headers map[string]string) Option {
+	return func(hc *HTTPClient) {
+		for k, v := range headers {
+			hc.headers[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+}
+
+// WithTransport 使用自定义传输层（如测试中的假实现），此时 WithHTTPClient、WithTimeout、WithRetries 不再生效
+func WithTransport(transport Transport) Option {
+	return func(hc *HTTPClient) {
+		hc.transport = transport
+	}
+}
+
 // NewHTTPClient 创建新的 HTTP 客户端
-func NewHTTPClient(baseURL string, sessionId string) *HTTPClient {
-	return &HTTPClient{
-		baseURL:   baseURL,
-		sessionId: sessionId,
+func NewHTTPClient(baseURL string, opts ...Option) *HTTPClient {
+	hc := &HTTPClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 5 * time.Minute, // 增加超时时间到 5 分钟
 		},
+		headers:          make(map[string]string),
+		screenshotFormat: "png",
 	}
-}
 
-// doRequest 执行 HTTP 请求
-func (hc *HTTPClient) doRequest(method, endpoint string, body any) (*HTTPResponse, error) {
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		reqBody = bytes.NewBuffer(jsonBody)
+	for _, opt := range opts {
+		opt(hc)
 	}
 
-	url := hc.baseURL + endpoint
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if hc.timeout != nil {
+		if hc.customHTTP {
+			client := *hc.httpClient
+			hc.httpClient = &client
+		}
+		hc.httpClient.Timeout = *hc.timeout
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if u, err := neturl.Parse(hc.baseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		hc.err = fmt.Errorf("invalid baseURL %q: must be an absolute URL such as http://localhost:3000", baseURL)
+	}
 
-	resp, err := hc.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+	if hc.transport == nil {
+		hc.transport = &httpTransport{
+			client:  hc.httpClient,
+			retries: hc.retries,
+		}
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	return hc
+}
+
+// SetDefaultHeader 设置每个请求都携带的请求头，应在发起请求前调用
+func (hc *HTTPClient) SetDefaultHeader(key, value string) {
+	hc.headers[http.CanonicalHeaderKey(key)] = value
+}
+
+// SetBearerToken 设置 Authorization: Bearer 请求头，token 为空时移除
+func (hc *HTTPClient) SetBearerToken(token string) {
+	if token == "" {
+		delete(hc.headers, "Authorization")
+		return
 	}
+	hc.headers["Authorization"] = "Bearer " + token
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+// SetLogger 设置请求日志回调，应在发起请求前调用；使用 WithTransport 自定义传输层时不生效
+// 回调不会收到请求头，Authorization 等敏感信息不会被记录
+func (hc *HTTPClient) SetLogger(logger Logger) {
+	if t, ok := hc.transport.(*httpTransport); ok {
+		t.logger = logger
 	}
+}
 
-	var httpResp HTTPResponse
-	if err := json.Unmarshal(respBody, &httpResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+// SetTransportConfig 调整默认 http.Transport 的连接池参数，复用空闲连接以减少大量抓取时的 TIME_WAIT 连接
+// 应在发起请求前调用；使用 WithHTTPClient 提供自定义客户端时不会修改其配置，返回错误
+func (hc *HTTPClient) SetTransportConfig(maxIdleConns, maxIdleConnsPerHost int, idleTimeout time.Duration) error {
+	if hc.customHTTP {
+		return fmt.Errorf("cannot configure transport of a custom http.Client")
 	}
 
-	if !httpResp.Success {
-		return nil, fmt.Errorf("server error: %s", httpResp.Error)
+	transport, ok := hc.httpClient.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
 	}
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.IdleConnTimeout = idleTimeout
+	hc.httpClient.Transport = transport
 
-	return &httpResp, nil
+	return nil
 }
 
-// doRequestBinary 执行 HTTP 请求并返回原始数据
-func (hc *HTTPClient) doRequestBinary(method, endpoint string, body any) ([]byte, error) {
-	var reqBody io.Reader
+// Err 返回创建客户端时的配置错误（如 baseURL 缺少协议），存在时所有请求都会返回该错误
+func (hc *HTTPClient) Err() error {
+	return hc.err
+}
+
+// newTransportRequest 构造传输层请求
+func (hc *HTTPClient) newTransportRequest(method, endpoint string, body any) (*TransportRequest, error) {
+	if hc.err != nil {
+		return nil, hc.err
+	}
+
+	req := &TransportRequest{
+		Method:   method,
+		URL:      hc.baseURL + "/" + strings.TrimLeft(endpoint, "/"),
+		Endpoint: endpoint,
+		Header:   make(http.Header),
+	}
+
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		req.Body = jsonBody
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range hc.headers {
+		req.Header.Set(k, v)
 	}
 
-	url := hc.baseURL + endpoint
-	req, err := http.NewRequest(method, url, reqBody)
+	return req, nil
+}
+
+// doRequest 执行 HTTP 请求
+func (hc *HTTPClient) doRequest(method, endpoint string, body any) (*HTTPResponse, error) {
+	req, err := hc.newTransportRequest(method, endpoint, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	return hc.transport.Do(req)
+}
 
-	resp, err := hc.httpClient.Do(req)
+// doRequestBinary 执行 HTTP 请求并返回原始数据
+func (hc *HTTPClient) doRequestBinary(method, endpoint string, body any) ([]byte, error) {
+	req, err := hc.newTransportRequest(method, endpoint, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	return hc.transport.DoBinary(req)
+}
+
+// pingTimeout Ping 的超时时间，独立于客户端默认超时
+const pingTimeout = 5 * time.Second
+
+// Ping 检查服务端是否可用，服务端返回 200 时返回 nil
+func (hc *HTTPClient) Ping() error {
+	req, err := hc.newTransportRequest("GET", "/api/health", nil)
+	if err != nil {
+		return err
 	}
+	req.Timeout = pingTimeout
+
+	_, err = hc.transport.DoBinary(req)
+	return err
+}
 
-	respBody, err := io.ReadAll(resp.Body)
+// BrowserVersion 浏览器版本信息
+type BrowserVersion struct {
+	Product         string // 如 Chrome/120.0.6099.109
+	ProtocolVersion string // CDP 协议版本
+	UserAgent       string
+}
+
+// Version 获取服务端管理的浏览器版本信息
+func (hc *HTTPClient) Version() (BrowserVersion, error) {
+	endpoint := fmt.Sprintf("/api/browser/version?sessionId=%s", hc.sessionId)
+
+	resp, err := hc.doRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return BrowserVersion{}, err
 	}
 
-	return respBody, nil
+	product, ok := resp.Data["product"].(string)
+	if !ok {
+		return BrowserVersion{}, fmt.Errorf("product not found in response")
+	}
+
+	version := BrowserVersion{Product: product}
+	version.ProtocolVersion, _ = resp.Data["protocolVersion"].(string)
+	version.UserAgent, _ = resp.Data["userAgent"].(string)
+
+	return version, nil
 }
 
 // StartBrowser 启动浏览器
@@ -157,6 +310,32 @@ func (hc *HTTPClient) Connect(port int) error {
 	return nil
 }
 
+// ConnectWithRetry 连接到已存在的浏览器，失败时按 interval 间隔重试，最多尝试 attempts 次
+func (hc *HTTPClient) ConnectWithRetry(ctx context.Context, port int, attempts int, interval time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = hc.Connect(port); err == nil {
+			return nil
+		}
+
+		if i == attempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	return fmt.Errorf("failed to connect after %d attempts: %w", attempts, err)
+}
+
 // Disconnect 断开连接
 func (hc *HTTPClient) Disconnect() error {
 	body := map[string]any{
@@ -177,25 +356,227 @@ func (hc *HTTPClient) StopBrowser() error {
 	return err
 }
 
+// SetAutoScheme 设置导航时是否为缺少协议的 URL（如 www.baidu.com）自动补全 https://
+func (hc *HTTPClient) SetAutoScheme(enabled bool) {
+	hc.autoScheme = enabled
+}
+
+// normalizeURL 校验导航 URL，开启 autoScheme 时补全协议
+func (hc *HTTPClient) normalizeURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", fmt.Errorf("invalid url: empty")
+	}
+
+	if hc.autoScheme && missingScheme(rawURL) {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+
+	if missingScheme(rawURL) {
+		return "", fmt.Errorf("invalid url %q: missing scheme", rawURL)
+	}
+
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return "", fmt.Errorf("invalid url %q: missing host", rawURL)
+	}
+
+	return u.String(), nil
+}
+
+// hostPortPattern 匹配缺少协议的 host:port（如 localhost:3000/login），url.Parse 会把其中的主机名解析为协议
+var hostPortPattern = regexp.MustCompile(`^[^:/?#]+:\d+([/?#]|$)`)
+
+// missingScheme 判断 URL 是否缺少协议，host:port 形式也视为缺少协议
+func missingScheme(rawURL string) bool {
+	if hostPortPattern.MatchString(rawURL) {
+		return true
+	}
+	u, err := neturl.Parse(rawURL)
+	return err == nil && u.Scheme == ""
+}
+
+// transientNavigationErrors 可重试的临时网络错误
+var transientNavigationErrors = []string{
+	"ERR_CONNECTION_RESET",
+	"ERR_CONNECTION_CLOSED",
+	"ERR_CONNECTION_ABORTED",
+	"ERR_CONNECTION_TIMED_OUT",
+	"ERR_TIMED_OUT",
+	"ERR_EMPTY_RESPONSE",
+	"ERR_NETWORK_CHANGED",
+	"ERR_HTTP2_PROTOCOL_ERROR",
+}
+
+// isTransientNavigationError 判断导航错误是否为可重试的临时网络错误
+// DNS 解析失败、URL 无效等错误不会重试
+func isTransientNavigationError(err error) bool {
+	msg := err.Error()
+	for _, code := range transientNavigationErrors {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNavigationRetry 设置导航遇到临时网络错误（如 net::ERR_CONNECTION_RESET）时的重试次数和间隔
+func (hc *HTTPClient) SetNavigationRetry(attempts int, backoff time.Duration) {
+	hc.navRetries = attempts
+	hc.navBackoff = backoff
+}
+
+// navigate 发送导航请求，遇到临时网络错误时按 SetNavigationRetry 的设置重试
+func (hc *HTTPClient) navigate(endpoint string, body map[string]any) (*HTTPResponse, error) {
+	resp, err := hc.doRequest("POST", endpoint, body)
+	for i := 0; i < hc.navRetries && err != nil && isTransientNavigationError(err); i++ {
+		time.Sleep(hc.navBackoff)
+		resp, err = hc.doRequest("POST", endpoint, body)
+	}
+	return resp, err
+}
+
 // Navigate 导航到 URL
 func (hc *HTTPClient) Navigate(url string) error {
+	url, err := hc.normalizeURL(url)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"url":       url,
+	}
+
+	_, err = hc.navigate("/api/page/navigate", body)
+	return err
+}
+
+// NavResponse 导航的主文档响应
+type NavResponse struct {
+	Status  int
+	Headers map[string]string
+	URL     string // 重定向后的最终 URL
+}
+
+// NavigateWithResponse 导航到 URL 并返回主文档的状态码和响应头，可用于识别 403 等拦截页面
+func (hc *HTTPClient) NavigateWithResponse(url string) (*NavResponse, error) {
+	url, err := hc.normalizeURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"url":       url,
+	}
+
+	resp, err := hc.navigate("/api/page/navigate", body)
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := resp.Data["status"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("status not found in response")
+	}
+
+	navResp := &NavResponse{
+		Status:  int(status),
+		Headers: make(map[string]string),
+	}
+	navResp.URL, _ = resp.Data["url"].(string)
+	if headers, ok := resp.Data["headers"].(map[string]any); ok {
+		for k, v := range headers {
+			if s, ok := v.(string); ok {
+				navResp.Headers[k] = s
+			}
+		}
+	}
+
+	return navResp, nil
+}
+
+// NavigateOptions 导航选项
+type NavigateOptions struct {
+	Referer   string        // 导航请求携带的 Referer，同时作为 document.referrer
+	WaitUntil string        // 等待的加载状态：load、domcontentloaded、networkidle，为空时使用服务端默认值
+	Timeout   time.Duration // 导航超时，0 表示使用服务端默认超时
+}
+
+// NavigateWithOptions 按选项导航到 URL
+func (hc *HTTPClient) NavigateWithOptions(url string, opts NavigateOptions) error {
+	if err := validateWaitUntil(opts.WaitUntil); err != nil {
+		return err
+	}
+
+	url, err := hc.normalizeURL(url)
+	if err != nil {
+		return err
+	}
+
 	body := map[string]any{
 		"sessionId": hc.sessionId,
 		"url":       url,
 	}
 
-	_, err := hc.doRequest("POST", "/api/page/navigate", body)
+	if opts.Referer != "" {
+		body["referer"] = opts.Referer
+	}
+	if opts.WaitUntil != "" {
+		body["waitUntil"] = opts.WaitUntil
+	}
+	if opts.Timeout > 0 {
+		body["timeout"] = opts.Timeout.Milliseconds()
+	}
+
+	_, err = hc.navigate("/api/page/navigate", body)
 	return err
 }
 
 // NavigateWithLoadedState 导航并等待加载完成
 func (hc *HTTPClient) NavigateWithLoadedState(url string) error {
+	url, err := hc.normalizeURL(url)
+	if err != nil {
+		return err
+	}
+
 	body := map[string]any{
 		"sessionId": hc.sessionId,
 		"url":       url,
 	}
 
-	_, err := hc.doRequest("POST", "/api/page/navigate-with-loaded-state", body)
+	_, err = hc.navigate("/api/page/navigate-with-loaded-state", body)
+	return err
+}
+
+// validateWaitUntil 校验加载状态，允许 load、domcontentloaded、networkidle，空字符串表示服务端默认值
+func validateWaitUntil(waitUntil string) error {
+	switch waitUntil {
+	case "", "load", "domcontentloaded", "networkidle":
+		return nil
+	default:
+		return fmt.Errorf("invalid waitUntil %q", waitUntil)
+	}
+}
+
+// SetContent 直接加载 HTML 内容，waitUntil 为 load、domcontentloaded 或 networkidle
+func (hc *HTTPClient) SetContent(html string, waitUntil string) error {
+	if err := validateWaitUntil(waitUntil); err != nil {
+		return err
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"html":      html,
+		"waitUntil": waitUntil,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/set-content", body)
 	return err
 }
 
@@ -234,6 +615,28 @@ func (hc *HTTPClient) ExecuteScript(script string) (any, error) {
 	return resp.Data["result"], nil
 }
 
+// Evaluate 执行 JavaScript 函数体，args 序列化为 JSON 数组后作为参数传入
+// 如 Evaluate("return document.querySelector(arguments[0]).innerText", "#title")，
+// 避免拼接脚本字符串带来的转义和注入问题
+func (hc *HTTPClient) Evaluate(script string, args ...any) (any, error) {
+	if args == nil {
+		args = []any{}
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"script":    script,
+		"args":      args,
+	}
+
+	resp, err := hc.doRequest("POST", "/api/page/evaluate", body)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data["result"], nil
+}
+
 // GetTitle 获取页面标题
 func (hc *HTTPClient) GetTitle() (string, error) {
 	endpoint := fmt.Sprintf("/api/page/title?sessionId=%s", hc.sessionId)
@@ -266,7 +669,7 @@ func (hc *HTTPClient) GetURL() (string, error) {
 	return "", fmt.Errorf("url not found in response")
 }
 
-// GetHTML 获取页面 HTML
+// GetHTML 获取页面 HTML，内容由服务端序列化，不保证包含 doctype；需要完整文档时使用 GetContent
 func (hc *HTTPClient) GetHTML() (string, error) {
 	endpoint := fmt.Sprintf("/api/page/html?sessionId=%s", hc.sessionId)
 
@@ -282,287 +685,1205 @@ func (hc *HTTPClient) GetHTML() (string, error) {
 	return "", fmt.Errorf("html not found in response")
 }
 
-// Screenshot 截图
-func (hc *HTTPClient) Screenshot(format string) ([]byte, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"format":    format,
+// contentScript 序列化完整文档，包含 doctype
+const contentScript = `(() => {
+	const doctype = document.doctype ? new XMLSerializer().serializeToString(document.doctype) : "";
+	return doctype + document.documentElement.outerHTML;
+})()`
+
+// GetContent 获取完整的页面 HTML，即 doctype（如有）加 document.documentElement.outerHTML
+func (hc *HTTPClient) GetContent() (string, error) {
+	result, err := hc.ExecuteScript(contentScript)
+	if err != nil {
+		return "", err
 	}
 
-	return hc.doRequestBinary("POST", "/api/page/screenshot", body)
+	if content, ok := result.(string); ok {
+		return content, nil
+	}
+
+	return "", fmt.Errorf("content not found in response")
 }
 
-// WaitForLoadStateLoad 等待页面加载完成
-func (hc *HTTPClient) WaitForLoadStateLoad() error {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
+// GetScrollPosition 获取页面滚动位置
+func (hc *HTTPClient) GetScrollPosition() (x, y int, err error) {
+	endpoint := fmt.Sprintf("/api/page/scroll-position?sessionId=%s", hc.sessionId)
+
+	resp, err := hc.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return 0, 0, err
 	}
 
-	_, err := hc.doRequest("POST", "/api/page/wait-for-load-state-load", body)
-	return err
+	fx, okX := resp.Data["x"].(float64)
+	fy, okY := resp.Data["y"].(float64)
+	if !okX || !okY {
+		return 0, 0, fmt.Errorf("scroll position not found in response")
+	}
+
+	return int(fx), int(fy), nil
 }
 
-// WaitForDomContentLoaded 等待 DOM 加载完成
-func (hc *HTTPClient) WaitForDomContentLoaded() error {
+// ScrollTo 滚动页面到绝对位置 (x, y)
+func (hc *HTTPClient) ScrollTo(x, y int) error {
 	body := map[string]any{
 		"sessionId": hc.sessionId,
+		"x":         x,
+		"y":         y,
 	}
 
-	_, err := hc.doRequest("POST", "/api/page/wait-for-dom-content-loaded", body)
+	_, err := hc.doRequest("POST", "/api/page/scroll", body)
 	return err
 }
 
-// WaitForSelectorVisible 等待选择器可见
-func (hc *HTTPClient) WaitForSelectorVisible(selector string) error {
+// ScrollBy 按像素偏移 (dx, dy) 滚动页面，负值表示向左或向上
+func (hc *HTTPClient) ScrollBy(dx, dy int) error {
 	body := map[string]any{
 		"sessionId": hc.sessionId,
-		"selector":  selector,
+		"deltaX":    dx,
+		"deltaY":    dy,
 	}
 
-	_, err := hc.doRequest("POST", "/api/page/wait-for-selector-visible", body)
+	_, err := hc.doRequest("POST", "/api/page/scroll", body)
 	return err
 }
 
-// ExpectResponseText 等待响应文本
-func (hc *HTTPClient) ExpectResponseText(urlOrPredicate, callback string) (string, error) {
-	body := map[string]any{
-		"sessionId":      hc.sessionId,
-		"urlOrPredicate": urlOrPredicate,
-		"callback":       callback,
-	}
+// FrameInfo 页面中的 frame 信息
+type FrameInfo struct {
+	Name     string // frame 名称
+	URL      string // frame 当前 URL
+	Selector string // 对应 iframe 元素的选择器，主 frame 为空
+}
 
-	resp, err := hc.doRequest("POST", "/api/page/expect-response-text", body)
+// GetFrames 获取页面中所有 frame
+func (hc *HTTPClient) GetFrames() ([]FrameInfo, error) {
+	endpoint := fmt.Sprintf("/api/page/frames?sessionId=%s", hc.sessionId)
+
+	resp, err := hc.doRequest("GET", endpoint, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if text, ok := resp.Data["text"].(string); ok {
-		return text, nil
+	frames, ok := resp.Data["frames"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("frames not found in response")
 	}
 
-	return "", fmt.Errorf("text not found in response")
+	result := make([]FrameInfo, 0, len(frames))
+	for _, f := range frames {
+		m, ok := f.(map[string]any)
+		if !ok {
+			continue
+		}
+		var info FrameInfo
+		info.Name, _ = m["name"].(string)
+		info.URL, _ = m["url"].(string)
+		info.Selector, _ = m["selector"].(string)
+		result = append(result, info)
+	}
+
+	return result, nil
 }
 
-// InnerText 获取内部文本
-func (hc *HTTPClient) InnerText(selector string) (string, error) {
+// Screenshot 截图，format 为 png、jpeg 或 webp，为空时使用默认格式
+func (hc *HTTPClient) Screenshot(format string) ([]byte, error) {
+	format, err := hc.screenshotFormatOrDefault(format)
+	if err != nil {
+		return nil, err
+	}
+
 	body := map[string]any{
 		"sessionId": hc.sessionId,
-		"selector":  selector,
+		"format":    format,
 	}
 
+	return hc.doRequestBinary("POST", "/api/page/screenshot", body)
+}
+
+// ScreenshotOptions 截图选项
+type ScreenshotOptions struct {
+	Format            string  // 图片格式：png、jpeg、webp，为空时使用默认格式
+	DeviceScaleFactor float64 // 设备像素比，0 表示使用浏览器默认值
+}
+
+// ScreenshotWithOptions 按选项截图
+func (hc *HTTPClient) ScreenshotWithOptions(opts ScreenshotOptions) ([]byte, error) {
+	format, err := hc.screenshotFormatOrDefault(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"format":    format,
+	}
+
+	if opts.DeviceScaleFactor > 0 {
+		body["deviceScaleFactor"] = opts.DeviceScaleFactor
+	}
+
+	return hc.doRequestBinary("POST", "/api/page/screenshot", body)
+}
+
+// WaitForLoadStateLoad 等待页面加载完成
+func (hc *HTTPClient) WaitForLoadStateLoad() error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-load-state-load", body)
+	return err
+}
+
+// WaitForDomContentLoaded 等待 DOM 加载完成
+func (hc *HTTPClient) WaitForDomContentLoaded() error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-dom-content-loaded", body)
+	return err
+}
+
+// WaitForNetworkIdle 等待网络空闲，即连续 idleTime 内没有网络请求（默认 500ms），超过 timeout 返回错误，timeout 为 0 时使用服务端默认超时
+func (hc *HTTPClient) WaitForNetworkIdle(idleTime time.Duration, timeout time.Duration) error {
+	if idleTime <= 0 {
+		idleTime = 500 * time.Millisecond
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"idleTime":  idleTime.Milliseconds(),
+	}
+
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-network-idle", body)
+	return err
+}
+
+// WaitForSelectorVisible 等待选择器可见
+func (hc *HTTPClient) WaitForSelectorVisible(selector string) error {
+	return hc.waitForSelectorVisible(selector, 0)
+}
+
+// waitForSelectorVisible 等待选择器可见，timeout 为 0 时使用服务端默认超时
+func (hc *HTTPClient) waitForSelectorVisible(selector string, timeout time.Duration) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"selector":  selector,
+	}
+
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-selector-visible", body)
+	return err
+}
+
+// WaitForSelectorHidden 等待元素隐藏，元素从 DOM 中移除或不可见都视为成功，用于等待加载动画消失
+// timeout 为 0 时使用服务端默认超时
+func (hc *HTTPClient) WaitForSelectorHidden(selector string, timeout time.Duration) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"selector":  selector,
+	}
+
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-selector-hidden", body)
+	return err
+}
+
+// RandomWait 在服务端随机等待 min 到 max 毫秒，用于模拟人工操作间隔
+func (hc *HTTPClient) RandomWait(min, max int) error {
+	if min < 0 || max < min {
+		return fmt.Errorf("invalid random wait range [%d, %d]", min, max)
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"min":       min,
+		"max":       max,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/random-wait", body)
+	return err
+}
+
+// randomWaitTiers 命名的随机等待区间（毫秒）
+var randomWaitTiers = map[string][2]int{
+	"short":  {500, 1500},
+	"middle": {1500, 3000},
+	"long":   {3000, 6000},
+}
+
+// RandomWaitTier 按命名区间随机等待：short（0.5-1.5s）、middle（1.5-3s）、long（3-6s）
+func (hc *HTTPClient) RandomWaitTier(tier string) error {
+	r, ok := randomWaitTiers[tier]
+	if !ok {
+		return fmt.Errorf("unknown random wait tier %q", tier)
+	}
+	return hc.RandomWait(r[0], r[1])
+}
+
+// WaitForURL 等待页面 URL 匹配
+// pattern 支持完整 URL 或 glob 通配符（如 "**/dashboard*"），超时返回错误，timeout 为 0 时使用服务端默认超时
+func (hc *HTTPClient) WaitForURL(pattern string, timeout time.Duration) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"pattern":   pattern,
+	}
+
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-url", body)
+	return err
+}
+
+// WaitForNavigation 执行 action 并等待其触发的导航完成
+// 先在服务端开始监听导航再执行 action，避免导航早于等待完成；waitUntil 为 load、domcontentloaded 或 networkidle
+// timeout 为 0 时使用服务端默认超时；action 失败时取消服务端的监听
+func (hc *HTTPClient) WaitForNavigation(action func() error, waitUntil string, timeout time.Duration) error {
+	if err := validateWaitUntil(waitUntil); err != nil {
+		return err
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"waitUntil": waitUntil,
+	}
+
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+
+	if _, err := hc.doRequest("POST", "/api/page/wait-for-navigation/start", body); err != nil {
+		return err
+	}
+
+	if err := action(); err != nil {
+		cancelBody := map[string]any{
+			"sessionId": hc.sessionId,
+		}
+		if _, cancelErr := hc.doRequest("POST", "/api/page/wait-for-navigation/cancel", cancelBody); cancelErr != nil {
+			return fmt.Errorf("%w (cancel wait: %v)", err, cancelErr)
+		}
+		return err
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/wait-for-navigation", body)
+	return err
+}
+
+// ExpectResponseText 等待响应文本
+func (hc *HTTPClient) ExpectResponseText(urlOrPredicate, callback string) (string, error) {
+	body := map[string]any{
+		"sessionId":      hc.sessionId,
+		"urlOrPredicate": urlOrPredicate,
+		"callback":       callback,
+	}
+
+	resp, err := hc.doRequest("POST", "/api/page/expect-response-text", body)
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := resp.Data["text"].(string); ok {
+		return text, nil
+	}
+
+	return "", fmt.Errorf("text not found in response")
+}
+
+// InnerText 获取内部文本
+func (hc *HTTPClient) InnerText(selector string) (string, error) {
+	return hc.innerText(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) innerText(body map[string]any) (string, error) {
 	resp, err := hc.doRequest("POST", "/api/page/inner-text", body)
 	if err != nil {
-		return "", err
+		return "", err
+	}
+
+	if text, ok := resp.Data["text"].(string); ok {
+		return text, nil
+	}
+
+	return "", fmt.Errorf("text not found in response")
+}
+
+// TextContent 获取文本内容
+func (hc *HTTPClient) TextContent(selector string) (string, error) {
+	return hc.textContent(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) textContent(body map[string]any) (string, error) {
+	resp, err := hc.doRequest("POST", "/api/page/must-text-content", body)
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := resp.Data["text"].(string); ok {
+		return text, nil
+	}
+
+	return "", fmt.Errorf("text not found in response")
+}
+
+// Close 关闭页面
+func (hc *HTTPClient) Close() error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/close", body)
+	return err
+}
+
+// NetworkConditions 网络环境参数
+type NetworkConditions struct {
+	Offline      bool
+	DownloadKbps int // 下载带宽（kbps），0 表示不限制
+	UploadKbps   int // 上传带宽（kbps），0 表示不限制
+	LatencyMs    int // 额外延迟（毫秒）
+}
+
+// networkPresets 常用网络环境预设，与 Chrome DevTools 保持一致
+var networkPresets = map[string]NetworkConditions{
+	"Slow 3G": {DownloadKbps: 400, UploadKbps: 400, LatencyMs: 2000},
+	"Fast 3G": {DownloadKbps: 1600, UploadKbps: 750, LatencyMs: 562},
+	"Offline": {Offline: true},
+}
+
+// NetworkPreset 获取命名的网络环境预设：Slow 3G、Fast 3G、Offline
+func NetworkPreset(name string) (NetworkConditions, error) {
+	conditions, ok := networkPresets[name]
+	if !ok {
+		return NetworkConditions{}, fmt.Errorf("unknown network preset %q", name)
+	}
+	return conditions, nil
+}
+
+// EmulateNetworkConditions 模拟网络环境
+func (hc *HTTPClient) EmulateNetworkConditions(offline bool, downloadKbps, uploadKbps, latencyMs int) error {
+	body := map[string]any{
+		"sessionId":    hc.sessionId,
+		"offline":      offline,
+		"downloadKbps": downloadKbps,
+		"uploadKbps":   uploadKbps,
+		"latencyMs":    latencyMs,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/emulate-network", body)
+	return err
+}
+
+// EmulateNetworkPreset 按预设模拟网络环境，如 Slow 3G
+func (hc *HTTPClient) EmulateNetworkPreset(name string) error {
+	c, err := NetworkPreset(name)
+	if err != nil {
+		return err
+	}
+	return hc.EmulateNetworkConditions(c.Offline, c.DownloadKbps, c.UploadKbps, c.LatencyMs)
+}
+
+// EmulateCPU 模拟 CPU 降速，rate 为降速倍数，1 表示不降速
+func (hc *HTTPClient) EmulateCPU(rate float64) error {
+	if rate < 1 {
+		return fmt.Errorf("invalid cpu throttling rate %v: must be >= 1", rate)
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"rate":      rate,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/emulate-cpu", body)
+	return err
+}
+
+// SetBasicAuth 设置 HTTP Basic 认证凭据，之后的导航自动携带；username 和 password 均为空时清除
+func (hc *HTTPClient) SetBasicAuth(username, password string) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"username":  username,
+		"password":  password,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/set-http-credentials", body)
+	return err
+}
+
+// EmulateMedia 模拟 CSS 媒体类型和配色方案
+// media 为 screen 或 print，colorScheme 为 light、dark 或 no-preference，空字符串表示保持不变
+func (hc *HTTPClient) EmulateMedia(media string, colorScheme string) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	switch media {
+	case "":
+	case "screen", "print":
+		body["media"] = media
+	default:
+		return fmt.Errorf("invalid media %q", media)
+	}
+
+	switch colorScheme {
+	case "":
+	case "light", "dark", "no-preference":
+		body["colorScheme"] = colorScheme
+	default:
+		return fmt.Errorf("invalid color scheme %q", colorScheme)
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/emulate-media", body)
+	return err
+}
+
+// PressKey 在当前焦点元素上按下并松开按键，如 Tab、Enter、Control+A
+func (hc *HTTPClient) PressKey(key string) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"key":       key,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/press-key", body)
+	return err
+}
+
+// PageError 页面未捕获的 JavaScript 错误
+type PageError struct {
+	Message string
+	Stack   string
+	Time    time.Time
+}
+
+// GetPageErrors 获取 since 之后页面抛出的未捕获 JavaScript 错误（pageerror 事件）
+func (hc *HTTPClient) GetPageErrors(since time.Time) ([]PageError, error) {
+	endpoint := fmt.Sprintf("/api/page/errors?sessionId=%s&since=%d", hc.sessionId, since.UnixMilli())
+
+	resp, err := hc.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := resp.Data["errors"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("errors not found in response")
+	}
+
+	result := make([]PageError, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		var pageErr PageError
+		pageErr.Message, _ = m["message"].(string)
+		pageErr.Stack, _ = m["stack"].(string)
+		if ts, ok := m["timestamp"].(float64); ok {
+			pageErr.Time = time.UnixMilli(int64(ts))
+		}
+		result = append(result, pageErr)
+	}
+
+	return result, nil
+}
+
+// AutoDismissDialogs 自动处理 alert、confirm、beforeunload 等对话框，accept 为 true 时确认，否则取消
+func (hc *HTTPClient) AutoDismissDialogs(accept bool) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"accept":    accept,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/auto-dialog", body)
+	return err
+}
+
+// SetMaxMatches 设置 AllTexts、AllAttributes、AllProperties、All 允许的最大匹配数量，0 表示不限制
+// 上限会随请求发送给服务端，由服务端截断结果
+// 默认超过上限时返回 ErrTooManyMatches，可通过 SetTruncateMatches 改为截断
+func (hc *HTTPClient) SetMaxMatches(n int) {
+	hc.maxMatches = n
+}
+
+// SetTruncateMatches 设置超过最大匹配数量时是否截断结果
+func (hc *HTTPClient) SetTruncateMatches(truncate bool) {
+	hc.truncateMatches = truncate
+}
+
+// applyMatchLimit 将最大匹配数量作为 limit 随请求发送，服务端只返回前 limit 个结果并在 total 中给出匹配总数，
+// 避免下载和解码超大响应
+func (hc *HTTPClient) applyMatchLimit(body map[string]any) {
+	if hc.maxMatches > 0 {
+		body["limit"] = hc.maxMatches
+	}
+}
+
+// matchedCount 返回匹配总数，服务端已按 limit 截断时以响应中的 total 为准
+func matchedCount(data map[string]any, n int) int {
+	if total, ok := data["total"].(float64); ok && int(total) > n {
+		return int(total)
+	}
+	return n
+}
+
+// limitMatches 按最大匹配数量检查 n，返回应保留的数量
+// 服务端未按 limit 截断时作为兜底检查
+func (hc *HTTPClient) limitMatches(n int) (int, error) {
+	if hc.maxMatches <= 0 || n <= hc.maxMatches {
+		return n, nil
+	}
+
+	if hc.truncateMatches {
+		return hc.maxMatches, nil
+	}
+
+	return 0, fmt.Errorf("%d elements matched, limit is %d: %w", n, hc.maxMatches, ErrTooManyMatches)
+}
+
+// selectorBody 构造元素请求体
+func (hc *HTTPClient) selectorBody(selector string) map[string]any {
+	return map[string]any{
+		"sessionId": hc.sessionId,
+		"selector":  selector,
+	}
+}
+
+// ElementExists 检查元素是否存在
+func (hc *HTTPClient) ElementExists(selector string) (bool, error) {
+	return hc.elementExists(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementExists(body map[string]any) (bool, error) {
+	resp, err := hc.doRequest("POST", "/api/element/exists", body)
+	if err != nil {
+		return false, err
+	}
+
+	if exists, ok := resp.Data["exists"].(bool); ok {
+		return exists, nil
+	}
+
+	return false, fmt.Errorf("exists not found in response")
+}
+
+// ElementText 获取元素文本
+func (hc *HTTPClient) ElementText(selector string) (string, error) {
+	return hc.elementText(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementText(body map[string]any) (string, error) {
+	resp, err := hc.doRequest("POST", "/api/element/text", body)
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := resp.Data["text"].(string); ok {
+		return text, nil
+	}
+
+	return "", fmt.Errorf("text not found in response")
+}
+
+// ElementClick 点击元素
+func (hc *HTTPClient) ElementClick(selector string) error {
+	return hc.elementClick(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementClick(body map[string]any) error {
+	_, err := hc.doRequest("POST", "/api/element/click", body)
+	return err
+}
+
+// ClickOptions 点击选项
+type ClickOptions struct {
+	Button     string   // 鼠标按键：left、right、middle，默认 left
+	ClickCount int      // 点击次数，默认 1
+	Modifiers  []string // 按住的修饰键：Control、Shift、Alt、Meta
+	PositionX  float64  // 相对元素左上角的点击位置，与 PositionY 同为 0 时点击元素中心
+	PositionY  float64
+	Delay      int // 按下与松开之间的间隔（毫秒）
+}
+
+// clickModifiers 允许的修饰键
+var clickModifiers = map[string]bool{
+	"Control": true,
+	"Shift":   true,
+	"Alt":     true,
+	"Meta":    true,
+}
+
+// ElementClickWithOptions 按选项点击元素
+func (hc *HTTPClient) ElementClickWithOptions(selector string, opts ClickOptions) error {
+	return hc.elementClickWithOptions(hc.selectorBody(selector), opts)
+}
+
+func (hc *HTTPClient) elementClickWithOptions(body map[string]any, opts ClickOptions) error {
+	for _, modifier := range opts.Modifiers {
+		if !clickModifiers[modifier] {
+			return fmt.Errorf("invalid click modifier %q", modifier)
+		}
+	}
+
+	if opts.Button != "" {
+		body["button"] = opts.Button
+	}
+	if opts.ClickCount > 0 {
+		body["clickCount"] = opts.ClickCount
+	}
+	if len(opts.Modifiers) > 0 {
+		body["modifiers"] = opts.Modifiers
+	}
+	if opts.PositionX != 0 || opts.PositionY != 0 {
+		body["position"] = map[string]any{
+			"x": opts.PositionX,
+			"y": opts.PositionY,
+		}
+	}
+	if opts.Delay > 0 {
+		body["delay"] = opts.Delay
+	}
+
+	return hc.elementClick(body)
+}
+
+// ElementHover 鼠标悬停
+func (hc *HTTPClient) ElementHover(selector string) error {
+	return hc.elementHover(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementHover(body map[string]any) error {
+	_, err := hc.doRequest("POST", "/api/element/hover", body)
+	return err
+}
+
+// ElementFocus 聚焦元素并触发 focus 事件，元素不可聚焦时返回错误
+func (hc *HTTPClient) ElementFocus(selector string) error {
+	return hc.elementFocus(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementFocus(body map[string]any) error {
+	_, err := hc.doRequest("POST", "/api/element/focus", body)
+	return err
+}
+
+// ElementBlur 使元素失去焦点并触发 blur 事件
+func (hc *HTTPClient) ElementBlur(selector string) error {
+	return hc.elementBlur(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementBlur(body map[string]any) error {
+	_, err := hc.doRequest("POST", "/api/element/blur", body)
+	return err
+}
+
+// ElementSetValue 设置元素值
+func (hc *HTTPClient) ElementSetValue(selector, value string) error {
+	return hc.elementSetValue(hc.selectorBody(selector), value)
+}
+
+func (hc *HTTPClient) elementSetValue(body map[string]any, value string) error {
+	body["value"] = value
+
+	_, err := hc.doRequest("POST", "/api/element/setValue", body)
+	return err
+}
+
+// ElementWait 等待元素
+func (hc *HTTPClient) ElementWait(selector string, timeout int) error {
+	return hc.elementWait(hc.selectorBody(selector), timeout)
+}
+
+func (hc *HTTPClient) elementWait(body map[string]any, timeout int) error {
+	body["timeout"] = timeout
+
+	_, err := hc.doRequest("POST", "/api/element/wait", body)
+	return err
+}
+
+// ElementWaitWithInterval 等待元素，服务端每隔 interval 检查一次，interval 为 0 时使用 defaultPollInterval（200ms）
+func (hc *HTTPClient) ElementWaitWithInterval(selector string, timeout, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	body := hc.selectorBody(selector)
+	body["interval"] = interval.Milliseconds()
+
+	return hc.elementWait(body, int(timeout.Milliseconds()))
+}
+
+// ElementWaitFor 等待元素达到指定状态：attached、detached、visible、hidden，timeout 为 0 时使用服务端默认超时
+func (hc *HTTPClient) ElementWaitFor(selector, state string, timeout time.Duration) error {
+	return hc.elementWaitFor(hc.selectorBody(selector), state, timeout)
+}
+
+func (hc *HTTPClient) elementWaitFor(body map[string]any, state string, timeout time.Duration) error {
+	switch state {
+	case "attached", "detached", "visible", "hidden":
+	default:
+		return fmt.Errorf("invalid element state %q", state)
+	}
+
+	body["state"] = state
+	if timeout > 0 {
+		body["timeout"] = timeout.Milliseconds()
+	}
+
+	_, err := hc.doRequest("POST", "/api/element/wait-for", body)
+	return err
+}
+
+// ElementAttribute 获取元素属性
+func (hc *HTTPClient) ElementAttribute(selector, attribute string) (string, error) {
+	return hc.elementAttribute(hc.selectorBody(selector), attribute)
+}
+
+func (hc *HTTPClient) elementAttribute(body map[string]any, attribute string) (string, error) {
+	body["attribute"] = attribute
+
+	resp, err := hc.doRequest("POST", "/api/element/attribute", body)
+	if err != nil {
+		return "", err
+	}
+
+	if value, ok := resp.Data["value"].(string); ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("value not found in response")
+}
+
+// ElementInnerHTML 获取元素内部 HTML
+func (hc *HTTPClient) ElementInnerHTML(selector string) (string, error) {
+	return hc.elementInnerHTML(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementInnerHTML(body map[string]any) (string, error) {
+	resp, err := hc.doRequest("POST", "/api/element/inner-html", body)
+	if err != nil {
+		return "", err
+	}
+
+	if html, ok := resp.Data["html"].(string); ok {
+		return html, nil
+	}
+
+	return "", fmt.Errorf("html not found in response")
+}
+
+// ElementProperty 获取元素 DOM 属性（如 value、checked），返回 JSON 解码后的值
+func (hc *HTTPClient) ElementProperty(selector, name string) (any, error) {
+	return hc.elementProperty(hc.selectorBody(selector), name)
+}
+
+func (hc *HTTPClient) elementProperty(body map[string]any, name string) (any, error) {
+	body["name"] = name
+
+	resp, err := hc.doRequest("POST", "/api/element/property", body)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := resp.Data["value"]
+	if !ok {
+		return nil, fmt.Errorf("value not found in response")
+	}
+
+	return value, nil
+}
+
+// ElementAllTexts 获取所有匹配元素的文本，等同于 ElementAllInnerTexts
+func (hc *HTTPClient) ElementAllTexts(selector string) ([]string, error) {
+	return hc.ElementAllInnerTexts(selector)
+}
+
+// ElementAllInnerTexts 获取所有匹配元素渲染后的文本（innerText，不含隐藏节点，空白按布局折叠）
+func (hc *HTTPClient) ElementAllInnerTexts(selector string) ([]string, error) {
+	return hc.elementAllTexts(hc.selectorBody(selector), "/api/element/all-texts")
+}
+
+// ElementAllTextContents 获取所有匹配元素的原始文本（textContent，包含隐藏节点和原始空白）
+func (hc *HTTPClient) ElementAllTextContents(selector string) ([]string, error) {
+	return hc.elementAllTexts(hc.selectorBody(selector), "/api/element/all-text-contents")
+}
+
+func (hc *HTTPClient) elementAllTexts(body map[string]any, endpoint string) ([]string, error) {
+	hc.applyMatchLimit(body)
+
+	resp, err := hc.doRequest("POST", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if texts, ok := resp.Data["texts"].([]any); ok {
+		n, err := hc.limitMatches(matchedCount(resp.Data, len(texts)))
+		if err != nil {
+			return nil, err
+		}
+
+		texts = texts[:min(n, len(texts))]
+		result := make([]string, len(texts))
+		for i, t := range texts {
+			if s, ok := t.(string); ok {
+				result[i] = s
+			}
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("texts not found in response")
+}
+
+// ElementAllAttributes 获取所有匹配元素的属性
+func (hc *HTTPClient) ElementAllAttributes(selector, attribute string) ([]string, error) {
+	return hc.elementAllAttributes(hc.selectorBody(selector), attribute)
+}
+
+func (hc *HTTPClient) elementAllAttributes(body map[string]any, attribute string) ([]string, error) {
+	body["attribute"] = attribute
+	hc.applyMatchLimit(body)
+
+	resp, err := hc.doRequest("POST", "/api/element/all-attributes", body)
+	if err != nil {
+		return nil, err
 	}
 
-	if text, ok := resp.Data["text"].(string); ok {
-		return text, nil
+	if attributes, ok := resp.Data["attributes"].([]any); ok {
+		n, err := hc.limitMatches(matchedCount(resp.Data, len(attributes)))
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = attributes[:min(n, len(attributes))]
+		result := make([]string, len(attributes))
+		for i, a := range attributes {
+			if s, ok := a.(string); ok {
+				result[i] = s
+			}
+		}
+		return result, nil
 	}
 
-	return "", fmt.Errorf("text not found in response")
+	return nil, fmt.Errorf("attributes not found in response")
 }
 
-// TextContent 获取文本内容
-func (hc *HTTPClient) TextContent(selector string) (string, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
+// ElementAllProperties 一次请求获取所有匹配元素的多个属性，每个元素返回一个属性名到属性值的映射
+// 属性名 text 表示元素文本，元素缺少的属性不出现在映射中
+func (hc *HTTPClient) ElementAllProperties(selector string, attrs ...string) ([]map[string]string, error) {
+	return hc.elementAllProperties(hc.selectorBody(selector), attrs)
+}
+
+func (hc *HTTPClient) elementAllProperties(body map[string]any, attrs []string) ([]map[string]string, error) {
+	body["attributes"] = attrs
+	hc.applyMatchLimit(body)
+
+	resp, err := hc.doRequest("POST", "/api/element/all-properties", body)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := hc.doRequest("POST", "/api/page/must-text-content", body)
+	properties, ok := resp.Data["properties"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("properties not found in response")
+	}
+
+	n, err := hc.limitMatches(matchedCount(resp.Data, len(properties)))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if text, ok := resp.Data["text"].(string); ok {
-		return text, nil
+	properties = properties[:min(n, len(properties))]
+	result := make([]map[string]string, len(properties))
+	for i, p := range properties {
+		result[i] = make(map[string]string)
+		m, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, value := range m {
+			if s, ok := value.(string); ok {
+				result[i][name] = s
+			}
+		}
 	}
 
-	return "", fmt.Errorf("text not found in response")
+	return result, nil
 }
 
-// Close 关闭页面
-func (hc *HTTPClient) Close() error {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
+// ElementCount 获取元素数量
+func (hc *HTTPClient) ElementCount(selector string) (int, error) {
+	return hc.elementCount(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementCount(body map[string]any) (int, error) {
+	resp, err := hc.doRequest("POST", "/api/element/count", body)
+	if err != nil {
+		return 0, err
 	}
 
-	_, err := hc.doRequest("POST", "/api/page/close", body)
-	return err
+	if count, ok := resp.Data["count"].(float64); ok {
+		return int(count), nil
+	}
+
+	return 0, fmt.Errorf("count not found in response")
 }
 
-// ElementExists 检查元素是否存在
-func (hc *HTTPClient) ElementExists(selector string) (bool, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-	}
+// ElementCountByAttribute 按属性值分组统计匹配元素数量
+func (hc *HTTPClient) ElementCountByAttribute(selector, attribute string) (map[string]int, error) {
+	return hc.elementCountByAttribute(hc.selectorBody(selector), attribute)
+}
 
-	resp, err := hc.doRequest("POST", "/api/element/exists", body)
+func (hc *HTTPClient) elementCountByAttribute(body map[string]any, attribute string) (map[string]int, error) {
+	body["attribute"] = attribute
+
+	resp, err := hc.doRequest("POST", "/api/element/count-by-attribute", body)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	if exists, ok := resp.Data["exists"].(bool); ok {
-		return exists, nil
+	if counts, ok := resp.Data["counts"].(map[string]any); ok {
+		result := make(map[string]int, len(counts))
+		for k, v := range counts {
+			if n, ok := v.(float64); ok {
+				result[k] = int(n)
+			}
+		}
+		return result, nil
 	}
 
-	return false, fmt.Errorf("exists not found in response")
+	return nil, fmt.Errorf("counts not found in response")
 }
 
-// ElementText 获取元素文本
-func (hc *HTTPClient) ElementText(selector string) (string, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-	}
+// ElementClickAndWaitForResponse 点击元素并等待 URL 匹配 urlPattern 的响应
+// 服务端先开始监听再点击，避免响应早于监听到达
+func (hc *HTTPClient) ElementClickAndWaitForResponse(selector, urlPattern string, timeout int) (*InterceptedResponse, error) {
+	return hc.elementClickAndWaitForResponse(hc.selectorBody(selector), urlPattern, timeout)
+}
 
-	resp, err := hc.doRequest("POST", "/api/element/text", body)
+func (hc *HTTPClient) elementClickAndWaitForResponse(body map[string]any, urlPattern string, timeout int) (*InterceptedResponse, error) {
+	body["urlPattern"] = urlPattern
+	body["timeout"] = timeout
+
+	resp, err := hc.doRequest("POST", "/api/element/click-and-wait-for-response", body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if text, ok := resp.Data["text"].(string); ok {
-		return text, nil
+	data, ok := resp.Data["response"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("response not found in response")
 	}
 
-	return "", fmt.Errorf("text not found in response")
+	return parseInterceptedResponse(data), nil
 }
 
-// ElementClick 点击元素
-func (hc *HTTPClient) ElementClick(selector string) error {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-	}
+// ElementDragTo 将元素拖放到目标元素中心
+func (hc *HTTPClient) ElementDragTo(sourceSelector, targetSelector string) error {
+	return hc.elementDragTo(hc.selectorBody(sourceSelector), map[string]any{"selector": targetSelector})
+}
 
-	_, err := hc.doRequest("POST", "/api/element/click", body)
+func (hc *HTTPClient) elementDragTo(body map[string]any, target map[string]any) error {
+	body["target"] = target
+
+	_, err := hc.doRequest("POST", "/api/element/drag-to", body)
 	return err
 }
 
-// ElementHover 鼠标悬停
-func (hc *HTTPClient) ElementHover(selector string) error {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-	}
+// ElementScrollIntoView 将元素滚动到可见区域
+func (hc *HTTPClient) ElementScrollIntoView(selector string) error {
+	return hc.elementScrollIntoView(hc.selectorBody(selector))
+}
 
-	_, err := hc.doRequest("POST", "/api/element/hover", body)
+func (hc *HTTPClient) elementScrollIntoView(body map[string]any) error {
+	_, err := hc.doRequest("POST", "/api/element/scroll-into-view", body)
 	return err
 }
 
-// ElementSetValue 设置元素值
-func (hc *HTTPClient) ElementSetValue(selector, value string) error {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-		"value":     value,
-	}
+// ElementScrollBy 按像素偏移 (dx, dy) 滚动可滚动的容器元素
+func (hc *HTTPClient) ElementScrollBy(selector string, dx, dy int) error {
+	return hc.elementScrollBy(hc.selectorBody(selector), dx, dy)
+}
 
-	_, err := hc.doRequest("POST", "/api/element/setValue", body)
+func (hc *HTTPClient) elementScrollBy(body map[string]any, dx, dy int) error {
+	body["deltaX"] = dx
+	body["deltaY"] = dy
+
+	_, err := hc.doRequest("POST", "/api/element/scroll-by", body)
 	return err
 }
 
-// ElementWait 等待元素
-func (hc *HTTPClient) ElementWait(selector string, timeout int) error {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-		"timeout":   timeout,
+// ElementDropFiles 模拟将本地文件拖放到元素上（DataTransfer），用于没有 <input type=file> 的上传控件
+func (hc *HTTPClient) ElementDropFiles(selector string, paths ...string) error {
+	return hc.elementDropFiles(hc.selectorBody(selector), paths...)
+}
+
+func (hc *HTTPClient) elementDropFiles(body map[string]any, paths ...string) error {
+	files := make([]map[string]any, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		mimeType := mime.TypeByExtension(filepath.Ext(path))
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+
+		files = append(files, map[string]any{
+			"name":     filepath.Base(path),
+			"mimeType": mimeType,
+			"buffer":   base64.StdEncoding.EncodeToString(data),
+		})
 	}
+	body["files"] = files
 
-	_, err := hc.doRequest("POST", "/api/element/wait", body)
+	_, err := hc.doRequest("POST", "/api/element/drop-files", body)
 	return err
 }
 
-// ElementAttribute 获取元素属性
-func (hc *HTTPClient) ElementAttribute(selector, attribute string) (string, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-		"attribute": attribute,
+// ElementScreenshot 元素截图
+func (hc *HTTPClient) ElementScreenshot(selector, format string) ([]byte, error) {
+	return hc.elementScreenshot(hc.selectorBody(selector), format)
+}
+
+func (hc *HTTPClient) elementScreenshot(body map[string]any, format string) ([]byte, error) {
+	format, err := hc.screenshotFormatOrDefault(format)
+	if err != nil {
+		return nil, err
 	}
+	body["format"] = format
 
-	resp, err := hc.doRequest("POST", "/api/element/attribute", body)
+	return hc.doRequestBinary("POST", "/api/element/screenshot", body)
+}
+
+// Rect 元素矩形区域
+type Rect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// ElementBoundingBox 获取元素位置和尺寸，元素未渲染时返回 ErrElementNotRendered
+func (hc *HTTPClient) ElementBoundingBox(selector string) (*Rect, error) {
+	return hc.elementBoundingBox(hc.selectorBody(selector))
+}
+
+func (hc *HTTPClient) elementBoundingBox(body map[string]any) (*Rect, error) {
+	resp, err := hc.doRequest("POST", "/api/element/bounding-box", body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if value, ok := resp.Data["value"].(string); ok {
-		return value, nil
+	box, ok := resp.Data["box"].(map[string]any)
+	if !ok {
+		return nil, ErrElementNotRendered
 	}
 
-	return "", fmt.Errorf("value not found in response")
+	rect := &Rect{}
+	rect.X, _ = box["x"].(float64)
+	rect.Y, _ = box["y"].(float64)
+	rect.Width, _ = box["width"].(float64)
+	rect.Height, _ = box["height"].(float64)
+
+	if rect.Width == 0 || rect.Height == 0 {
+		return nil, ErrElementNotRendered
+	}
+
+	return rect, nil
 }
 
-// ElementAllTexts 获取所有匹配元素的文本
-func (hc *HTTPClient) ElementAllTexts(selector string) ([]string, error) {
+// ElementScreenshots 一次请求截取多个元素，返回按选择器索引的图片数据
+func (hc *HTTPClient) ElementScreenshots(selectors []string, format string) (map[string][]byte, error) {
+	format, err := hc.screenshotFormatOrDefault(format)
+	if err != nil {
+		return nil, err
+	}
+
 	body := map[string]any{
 		"sessionId": hc.sessionId,
-		"selector":  selector,
+		"selectors": selectors,
+		"format":    format,
 	}
 
-	resp, err := hc.doRequest("POST", "/api/element/all-texts", body)
+	resp, err := hc.doRequest("POST", "/api/element/screenshot-batch", body)
 	if err != nil {
 		return nil, err
 	}
 
-	if texts, ok := resp.Data["texts"].([]any); ok {
-		result := make([]string, len(texts))
-		for i, t := range texts {
-			if s, ok := t.(string); ok {
-				result[i] = s
-			}
+	screenshots, ok := resp.Data["screenshots"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("screenshots not found in response")
+	}
+
+	result := make(map[string][]byte, len(screenshots))
+	for selector, v := range screenshots {
+		encoded, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid screenshot for selector %q", selector)
 		}
-		return result, nil
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode screenshot for selector %q: %w", selector, err)
+		}
+		result[selector] = data
 	}
 
-	return nil, fmt.Errorf("texts not found in response")
+	return result, nil
 }
 
-// ElementAllAttributes 获取所有匹配元素的属性
-func (hc *HTTPClient) ElementAllAttributes(selector, attribute string) ([]string, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-		"attribute": attribute,
-	}
+// EvalOnAll 对所有匹配元素执行一次 JavaScript 函数，函数接收元素数组作为参数，返回 JSON 解码后的数组
+// 如 EvalOnAll(".job", "els => els.map(e => e.dataset.id)")
+func (hc *HTTPClient) EvalOnAll(selector, script string) ([]any, error) {
+	return hc.evalOnAll(hc.selectorBody(selector), script)
+}
 
-	resp, err := hc.doRequest("POST", "/api/element/all-attributes", body)
+func (hc *HTTPClient) evalOnAll(body map[string]any, script string) ([]any, error) {
+	body["script"] = script
+
+	resp, err := hc.doRequest("POST", "/api/element/eval-all", body)
 	if err != nil {
 		return nil, err
 	}
 
-	if attributes, ok := resp.Data["attributes"].([]any); ok {
-		result := make([]string, len(attributes))
-		for i, a := range attributes {
-			if s, ok := a.(string); ok {
-				result[i] = s
-			}
-		}
+	if result, ok := resp.Data["result"].([]any); ok {
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("attributes not found in response")
+	return nil, fmt.Errorf("result not found in response")
 }
 
-// ElementCount 获取元素数量
-func (hc *HTTPClient) ElementCount(selector string) (int, error) {
-	body := map[string]any{
-		"sessionId": hc.sessionId,
-		"selector":  selector,
-	}
+// ElementDOMTree 获取元素的 DOM 子树（tag、attributes、children），maxDepth 限制层级深度
+func (hc *HTTPClient) ElementDOMTree(selector string, maxDepth int) (map[string]any, error) {
+	return hc.elementDOMTree(hc.selectorBody(selector), maxDepth)
+}
 
-	resp, err := hc.doRequest("POST", "/api/element/count", body)
+func (hc *HTTPClient) elementDOMTree(body map[string]any, maxDepth int) (map[string]any, error) {
+	body["maxDepth"] = maxDepth
+
+	resp, err := hc.doRequest("POST", "/api/element/dom-tree", body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	if count, ok := resp.Data["count"].(float64); ok {
-		return int(count), nil
+	if tree, ok := resp.Data["tree"].(map[string]any); ok {
+		return tree, nil
 	}
 
-	return 0, fmt.Errorf("count not found in response")
+	return nil, fmt.Errorf("tree not found in response")
 }
 
 // ========== 网络监听器 ==========
@@ -611,3 +1932,70 @@ func (hc *HTTPClient) ClearNetworkCache(pattern string) error {
 	_, err := hc.doRequest("POST", "/api/network/clear-cache", body)
 	return err
 }
+
+// ========== HAR 录制 ==========
+
+// StartHARRecording 开始录制 HAR，记录之后的请求、响应、状态码和耗时
+func (hc *HTTPClient) StartHARRecording() error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/har/start", body)
+	return err
+}
+
+// StopHARRecording 停止录制并返回 HAR JSON
+func (hc *HTTPClient) StopHARRecording() ([]byte, error) {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	return hc.doRequestBinary("POST", "/api/page/har/stop", body)
+}
+
+// ========== 性能追踪 ==========
+
+// defaultTracingCategories 未指定时使用的追踪类别
+var defaultTracingCategories = []string{"devtools.timeline", "disabled-by-default-devtools.timeline"}
+
+// StartTracing 开始 Chrome 性能追踪，categories 为空时使用 devtools.timeline
+func (hc *HTTPClient) StartTracing(categories []string) error {
+	if len(categories) == 0 {
+		categories = defaultTracingCategories
+	}
+
+	body := map[string]any{
+		"sessionId":  hc.sessionId,
+		"categories": categories,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/tracing/start", body)
+	return err
+}
+
+// StopTracing 停止性能追踪并返回 trace JSON，可在 DevTools Performance 面板中打开
+func (hc *HTTPClient) StopTracing() ([]byte, error) {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	return hc.doRequestBinary("POST", "/api/page/tracing/stop", body)
+}
+
+// ========== Cookie ==========
+
+// DeleteCookie 删除指定名称的 cookie，domain 为空时删除所有域下的同名 cookie
+func (hc *HTTPClient) DeleteCookie(name, domain string) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"name":      name,
+	}
+
+	if domain != "" {
+		body["domain"] = domain
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/delete-cookie", body)
+	return err
+}
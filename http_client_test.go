@@ -0,0 +1,1041 @@
+package cdpsdk
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWaitForURL(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.WaitForURL("**/dashboard*", 3*time.Second); err != nil {
+		t.Fatalf("WaitForURL: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertEndpoints(t, fb, "/api/page/wait-for-url")
+	assertBody(t, call, "pattern", "**/dashboard*")
+	assertBody(t, call, "timeout", 3000)
+
+	if err := hc.WaitForURL("**/dashboard*", 0); err != nil {
+		t.Fatalf("WaitForURL: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "timeout")
+}
+
+func TestElementDOMTree(t *testing.T) {
+	tree := map[string]any{
+		"tag":        "ul",
+		"attributes": map[string]any{"class": "menu"},
+		"children": []any{
+			map[string]any{"tag": "li", "attributes": map[string]any{}, "children": []any{}},
+		},
+	}
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"tree": tree}, nil
+	})
+
+	got, err := hc.ElementDOMTree("ul.menu", 2)
+	if err != nil {
+		t.Fatalf("ElementDOMTree: %v", err)
+	}
+	if !reflect.DeepEqual(got, tree) {
+		t.Errorf("tree = %v", got)
+	}
+	assertBody(t, fb.lastCall(t), "maxDepth", 2)
+}
+
+func TestConnectWithRetry(t *testing.T) {
+	var attempts int
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("connection refused")
+		}
+		return map[string]any{"sessionId": "connected"}, nil
+	})
+
+	if err := hc.ConnectWithRetry(context.Background(), 9222, 5, time.Millisecond); err != nil {
+		t.Fatalf("ConnectWithRetry: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if hc.sessionId != "connected" {
+		t.Errorf("sessionId = %q, want connected", hc.sessionId)
+	}
+}
+
+func TestConnectWithRetryGivesUp(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	err := hc.ConnectWithRetry(context.Background(), 9222, 2, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Fatalf("ConnectWithRetry error = %v", err)
+	}
+	if n := len(fb.Calls()); n != 2 {
+		t.Errorf("attempts = %d, want 2", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := hc.ConnectWithRetry(ctx, 9222, 5, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("ConnectWithRetry error = %v, want context.Canceled", err)
+	}
+}
+
+func TestScreenshotWithOptions(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+	fb.binary = func(call fakeCall) ([]byte, error) {
+		return []byte("image"), nil
+	}
+
+	data, err := hc.ScreenshotWithOptions(ScreenshotOptions{Format: "jpeg", DeviceScaleFactor: 2})
+	if err != nil {
+		t.Fatalf("ScreenshotWithOptions: %v", err)
+	}
+	if string(data) != "image" {
+		t.Errorf("data = %q", data)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "format", "jpeg")
+	assertBody(t, call, "deviceScaleFactor", 2)
+
+	if _, err := hc.ScreenshotWithOptions(ScreenshotOptions{Format: "png"}); err != nil {
+		t.Fatalf("ScreenshotWithOptions: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "deviceScaleFactor")
+}
+
+func TestAutoDismissDialogs(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	for _, accept := range []bool{true, false} {
+		if err := hc.AutoDismissDialogs(accept); err != nil {
+			t.Fatalf("AutoDismissDialogs: %v", err)
+		}
+		assertBody(t, fb.lastCall(t), "accept", accept)
+	}
+}
+
+func TestElementClickAndWaitForResponse(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"response": map[string]any{
+			"url":     "https://example.com/api/save",
+			"status":  201,
+			"headers": map[string]any{"content-type": "application/json"},
+			"body":    `{"id":1}`,
+		}}, nil
+	})
+
+	resp, err := hc.ElementClickAndWaitForResponse("#save", "**/api/save", 5000)
+	if err != nil {
+		t.Fatalf("ElementClickAndWaitForResponse: %v", err)
+	}
+	if resp.URL != "https://example.com/api/save" || resp.Status != 201 || resp.Body != `{"id":1}` {
+		t.Errorf("response = %+v", resp)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "urlPattern", "**/api/save")
+	assertBody(t, call, "timeout", 5000)
+}
+
+func TestElementBoundingBox(t *testing.T) {
+	box := map[string]any{"x": 10.5, "y": 20, "width": 100, "height": 40}
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		if call.Body["selector"] == "#hidden" {
+			return map[string]any{"box": nil}, nil
+		}
+		if call.Body["selector"] == "#empty" {
+			return map[string]any{"box": map[string]any{"x": 0, "y": 0, "width": 0, "height": 0}}, nil
+		}
+		return map[string]any{"box": box}, nil
+	})
+
+	rect, err := hc.ElementBoundingBox("#button")
+	if err != nil {
+		t.Fatalf("ElementBoundingBox: %v", err)
+	}
+	if *rect != (Rect{X: 10.5, Y: 20, Width: 100, Height: 40}) {
+		t.Errorf("rect = %+v", *rect)
+	}
+
+	for _, selector := range []string{"#hidden", "#empty"} {
+		if _, err := hc.ElementBoundingBox(selector); !errors.Is(err, ErrElementNotRendered) {
+			t.Errorf("ElementBoundingBox(%q) error = %v, want ErrElementNotRendered", selector, err)
+		}
+	}
+}
+
+func TestElementCountByAttribute(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"counts": map[string]any{"open": 3, "closed": 1}}, nil
+	})
+
+	counts, err := hc.ElementCountByAttribute(".job", "data-status")
+	if err != nil {
+		t.Fatalf("ElementCountByAttribute: %v", err)
+	}
+	if !reflect.DeepEqual(counts, map[string]int{"open": 3, "closed": 1}) {
+		t.Errorf("counts = %v", counts)
+	}
+	assertBody(t, fb.lastCall(t), "attribute", "data-status")
+}
+
+func TestElementDragTo(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.ElementDragTo("#card", "#done"); err != nil {
+		t.Fatalf("ElementDragTo: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selector", "#card")
+	assertBody(t, call, "target", map[string]any{"selector": "#done"})
+}
+
+func TestConcurrentElementText(t *testing.T) {
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"text": "text of " + call.Body["selector"].(string)}, nil
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			selector := fmt.Sprintf("#item-%d", i)
+			text, err := hc.ElementText(selector)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if text != "text of "+selector {
+				errs <- fmt.Errorf("ElementText(%q) = %q", selector, text)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestElementClickWithOptions(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	err := hc.ElementClickWithOptions("#item", ClickOptions{
+		Button:     "right",
+		ClickCount: 2,
+		Modifiers:  []string{"Control", "Shift"},
+		PositionX:  5,
+		PositionY:  10,
+		Delay:      50,
+	})
+	if err != nil {
+		t.Fatalf("ElementClickWithOptions: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "button", "right")
+	assertBody(t, call, "clickCount", 2)
+	assertBody(t, call, "modifiers", []string{"Control", "Shift"})
+	assertBody(t, call, "position", map[string]any{"x": 5, "y": 10})
+	assertBody(t, call, "delay", 50)
+
+	if err := hc.ElementClickWithOptions("#item", ClickOptions{}); err != nil {
+		t.Fatalf("ElementClickWithOptions: %v", err)
+	}
+	call = fb.lastCall(t)
+	for _, key := range []string{"button", "clickCount", "modifiers", "position", "delay"} {
+		assertNoBodyKey(t, call, key)
+	}
+
+	if err := hc.ElementClickWithOptions("#item", ClickOptions{Modifiers: []string{"Ctrl"}}); err == nil {
+		t.Error("ElementClickWithOptions: expected error for invalid modifier")
+	}
+}
+
+func TestElementInnerHTMLAndProperty(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/element/inner-html":
+			return map[string]any{"html": "<b>bold</b>"}, nil
+		case "/api/element/property":
+			return map[string]any{"value": true}, nil
+		}
+		return nil, nil
+	})
+
+	html, err := hc.ElementInnerHTML("#content")
+	if err != nil {
+		t.Fatalf("ElementInnerHTML: %v", err)
+	}
+	if html != "<b>bold</b>" {
+		t.Errorf("html = %q", html)
+	}
+
+	checked, err := hc.ElementProperty("#agree", "checked")
+	if err != nil {
+		t.Fatalf("ElementProperty: %v", err)
+	}
+	if checked != true {
+		t.Errorf("checked = %v, want true", checked)
+	}
+	assertBody(t, fb.lastCall(t), "name", "checked")
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		autoScheme bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "https", url: "https://example.com/path", want: "https://example.com/path"},
+		{name: "trimmed", url: "  http://example.com  ", want: "http://example.com"},
+		{name: "file", url: "file:///tmp/index.html", want: "file:///tmp/index.html"},
+		{name: "about blank", url: "about:blank", want: "about:blank"},
+		{name: "data url", url: "data:text/html,<p>hi</p>", want: "data:text/html,<p>hi</p>"},
+		{name: "empty", url: "", wantErr: true},
+		{name: "missing scheme", url: "www.baidu.com", wantErr: true},
+		{name: "host and port", url: "localhost:3000/login", wantErr: true},
+		{name: "garbage", url: "http://[::1", wantErr: true},
+		{name: "missing host", url: "https://", wantErr: true},
+		{name: "host and port without path", url: "example.com:8080", wantErr: true},
+		{name: "chrome page", url: "chrome://version", want: "chrome://version"},
+		{name: "view source", url: "view-source:https://example.com", want: "view-source:https://example.com"},
+		{name: "mailto", url: "mailto:a@b.com", want: "mailto:a@b.com"},
+		{name: "auto scheme", url: "www.baidu.com", autoScheme: true, want: "https://www.baidu.com"},
+		{name: "auto scheme host and port", url: "localhost:3000/login", autoScheme: true, want: "https://localhost:3000/login"},
+		{name: "auto scheme keeps about", url: "about:blank", autoScheme: true, want: "about:blank"},
+		{name: "auto scheme keeps mailto", url: "mailto:a@b.com", autoScheme: true, want: "mailto:a@b.com"},
+		{name: "auto scheme keeps chrome", url: "chrome://version", autoScheme: true, want: "chrome://version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := NewHTTPClient("http://localhost:3000")
+			hc.SetAutoScheme(tt.autoScheme)
+
+			got, err := hc.normalizeURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("normalizeURL(%q) = %q, want error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeURL(%q): %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNavigateRejectsInvalidURL(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.Navigate("localhost:3000/login"); err == nil {
+		t.Fatal("Navigate: expected error")
+	}
+	if calls := fb.Calls(); len(calls) != 0 {
+		t.Errorf("sent %d requests for an invalid URL", len(calls))
+	}
+}
+
+func TestSetContent(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.SetContent("<p>hello</p>", "domcontentloaded"); err != nil {
+		t.Fatalf("SetContent: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertEndpoints(t, fb, "/api/page/set-content")
+	assertBody(t, call, "html", "<p>hello</p>")
+	assertBody(t, call, "waitUntil", "domcontentloaded")
+
+	if err := hc.SetContent("<p>hello</p>", "ready"); err == nil {
+		t.Error("SetContent: expected error for invalid waitUntil")
+	}
+}
+
+func TestGetFrames(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"frames": []any{
+			map[string]any{"name": "", "url": "https://example.com"},
+			map[string]any{"name": "login", "url": "https://auth.example.com", "selector": "iframe#login"},
+		}}, nil
+	})
+
+	frames, err := hc.GetFrames()
+	if err != nil {
+		t.Fatalf("GetFrames: %v", err)
+	}
+	want := []FrameInfo{
+		{URL: "https://example.com"},
+		{Name: "login", URL: "https://auth.example.com", Selector: "iframe#login"},
+	}
+	if !reflect.DeepEqual(frames, want) {
+		t.Errorf("frames = %+v, want %+v", frames, want)
+	}
+	if call := fb.lastCall(t); call.Method != "GET" || call.Query.Get("sessionId") != "test-session" {
+		t.Errorf("request = %s %v", call.Method, call.Query)
+	}
+}
+
+func TestElementScrolling(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.ElementScrollIntoView("#footer"); err != nil {
+		t.Fatalf("ElementScrollIntoView: %v", err)
+	}
+	if err := hc.ElementScrollBy(".list", 0, 300); err != nil {
+		t.Fatalf("ElementScrollBy: %v", err)
+	}
+	assertEndpoints(t, fb, "/api/element/scroll-into-view", "/api/element/scroll-by")
+	call := fb.lastCall(t)
+	assertBody(t, call, "deltaX", 0)
+	assertBody(t, call, "deltaY", 300)
+}
+
+func TestDeleteCookie(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.DeleteCookie("token", ".example.com"); err != nil {
+		t.Fatalf("DeleteCookie: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "name", "token")
+	assertBody(t, call, "domain", ".example.com")
+
+	if err := hc.DeleteCookie("token", ""); err != nil {
+		t.Fatalf("DeleteCookie: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "domain")
+}
+
+func TestMatchLimit(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		// 服务端按 limit 截断，并在 total 中返回匹配总数
+		return map[string]any{"texts": []any{"a", "b"}, "total": 5}, nil
+	})
+	hc.SetMaxMatches(2)
+
+	if _, err := hc.ElementAllTexts(".item"); !errors.Is(err, ErrTooManyMatches) {
+		t.Fatalf("ElementAllTexts error = %v, want ErrTooManyMatches", err)
+	}
+	assertBody(t, fb.lastCall(t), "limit", 2)
+
+	hc.SetTruncateMatches(true)
+	texts, err := hc.ElementAllTexts(".item")
+	if err != nil {
+		t.Fatalf("ElementAllTexts: %v", err)
+	}
+	if !reflect.DeepEqual(texts, []string{"a", "b"}) {
+		t.Errorf("texts = %v", texts)
+	}
+}
+
+func TestEvaluateArgs(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"result": "Title"}, nil
+	})
+
+	result, err := hc.Evaluate("return document.querySelector(arguments[0]).innerText", `#it's "quoted"`, 1)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if result != "Title" {
+		t.Errorf("result = %v, want Title", result)
+	}
+	assertBody(t, fb.lastCall(t), "args", []any{`#it's "quoted"`, 1})
+
+	if _, err := hc.Evaluate("return 1"); err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "args", []any{})
+}
+
+func TestElementScreenshots(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"screenshots": map[string]any{
+			"#a": base64.StdEncoding.EncodeToString([]byte("png-a")),
+			"#b": base64.StdEncoding.EncodeToString([]byte("png-b")),
+		}}, nil
+	})
+
+	shots, err := hc.ElementScreenshots([]string{"#a", "#b"}, "")
+	if err != nil {
+		t.Fatalf("ElementScreenshots: %v", err)
+	}
+	if string(shots["#a"]) != "png-a" || string(shots["#b"]) != "png-b" {
+		t.Errorf("screenshots = %q", shots)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selectors", []string{"#a", "#b"})
+	assertBody(t, call, "format", "png")
+}
+
+func TestGetPageErrors(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"errors": []any{
+			map[string]any{"message": "x is undefined", "stack": "at app.js:1", "timestamp": 1700000000000},
+		}}, nil
+	})
+
+	since := time.UnixMilli(1690000000000)
+	errs, err := hc.GetPageErrors(since)
+	if err != nil {
+		t.Fatalf("GetPageErrors: %v", err)
+	}
+	want := []PageError{{Message: "x is undefined", Stack: "at app.js:1", Time: time.UnixMilli(1700000000000)}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors = %+v, want %+v", errs, want)
+	}
+	if got := fb.lastCall(t).Query.Get("since"); got != "1690000000000" {
+		t.Errorf("since = %q", got)
+	}
+}
+
+func TestElementFocusAndBlur(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.ElementFocus("#email"); err != nil {
+		t.Fatalf("ElementFocus: %v", err)
+	}
+	if err := hc.ElementBlur("#email"); err != nil {
+		t.Fatalf("ElementBlur: %v", err)
+	}
+	assertEndpoints(t, fb, "/api/element/focus", "/api/element/blur")
+}
+
+func TestNavigationRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       string
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "transient", err: "server error: net::ERR_CONNECTION_RESET", wantCalls: 2},
+		{name: "permanent", err: "server error: net::ERR_NAME_NOT_RESOLVED", wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+				calls++
+				if calls == 1 {
+					return nil, errors.New(tt.err)
+				}
+				return nil, nil
+			})
+			hc.SetNavigationRetry(3, time.Millisecond)
+
+			err := hc.Navigate("https://example.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Navigate error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("navigate sent %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForNavigationOrder(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	err := hc.WaitForNavigation(func() error {
+		return hc.ElementClick("#submit")
+	}, "load", 5*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForNavigation: %v", err)
+	}
+	assertEndpoints(t, fb,
+		"/api/page/wait-for-navigation/start",
+		"/api/element/click",
+		"/api/page/wait-for-navigation",
+	)
+	assertBody(t, fb.lastCall(t), "timeout", 5000)
+
+	if err := hc.WaitForNavigation(func() error { return nil }, "load", 0); err != nil {
+		t.Fatalf("WaitForNavigation: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "timeout")
+}
+
+func TestWaitForNavigationActionError(t *testing.T) {
+	var cancelErr error
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/page/wait-for-navigation/cancel" {
+			return nil, cancelErr
+		}
+		return nil, nil
+	})
+
+	// action 失败时取消已开始的监听，不再等待导航
+	actionErr := errors.New("click failed")
+	if err := hc.WaitForNavigation(func() error { return actionErr }, "load", time.Second); !errors.Is(err, actionErr) {
+		t.Fatalf("WaitForNavigation error = %v, want %v", err, actionErr)
+	}
+	assertEndpoints(t, fb,
+		"/api/page/wait-for-navigation/start",
+		"/api/page/wait-for-navigation/cancel",
+	)
+	assertBody(t, fb.lastCall(t), "sessionId", "test-session")
+
+	cancelErr = errors.New("no pending navigation")
+	err := hc.WaitForNavigation(func() error { return actionErr }, "load", time.Second)
+	if !errors.Is(err, actionErr) || !strings.Contains(err.Error(), "no pending navigation") {
+		t.Errorf("WaitForNavigation error = %v, want %v with the cancel error", err, actionErr)
+	}
+}
+
+func TestScrollPosition(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"x": 10, "y": 250}, nil
+	})
+
+	x, y, err := hc.GetScrollPosition()
+	if err != nil {
+		t.Fatalf("GetScrollPosition: %v", err)
+	}
+	if x != 10 || y != 250 {
+		t.Errorf("position = (%d, %d), want (10, 250)", x, y)
+	}
+
+	if err := hc.ScrollTo(0, 500); err != nil {
+		t.Fatalf("ScrollTo: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "x", 0)
+	assertBody(t, call, "y", 500)
+
+	if err := hc.ScrollBy(0, -100); err != nil {
+		t.Fatalf("ScrollBy: %v", err)
+	}
+	call = fb.lastCall(t)
+	assertBody(t, call, "deltaX", 0)
+	assertBody(t, call, "deltaY", -100)
+}
+
+func TestWaitForNetworkIdle(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.WaitForNetworkIdle(0, 10*time.Second); err != nil {
+		t.Fatalf("WaitForNetworkIdle: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "idleTime", 500)
+	assertBody(t, call, "timeout", 10000)
+
+	if err := hc.WaitForNetworkIdle(time.Second, 10*time.Second); err != nil {
+		t.Fatalf("WaitForNetworkIdle: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "idleTime", 1000)
+
+	if err := hc.WaitForNetworkIdle(0, 0); err != nil {
+		t.Fatalf("WaitForNetworkIdle: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "timeout")
+}
+
+func TestGetContent(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"result": "<!DOCTYPE html><html><body></body></html>"}, nil
+	})
+
+	content, err := hc.GetContent()
+	if err != nil {
+		t.Fatalf("GetContent: %v", err)
+	}
+	if !strings.HasPrefix(content, "<!DOCTYPE html>") {
+		t.Errorf("content = %q, want DOCTYPE prefix", content)
+	}
+	assertEndpoints(t, fb, "/api/page/execute")
+}
+
+func TestEmulateMedia(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.EmulateMedia("print", "dark"); err != nil {
+		t.Fatalf("EmulateMedia: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "media", "print")
+	assertBody(t, call, "colorScheme", "dark")
+
+	if err := hc.EmulateMedia("", "light"); err != nil {
+		t.Fatalf("EmulateMedia: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "media")
+
+	if err := hc.EmulateMedia("tv", ""); err == nil {
+		t.Error("EmulateMedia: expected error for invalid media")
+	}
+	if err := hc.EmulateMedia("", "sepia"); err == nil {
+		t.Error("EmulateMedia: expected error for invalid color scheme")
+	}
+}
+
+func TestSetBasicAuth(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.SetBasicAuth("admin", "secret"); err != nil {
+		t.Fatalf("SetBasicAuth: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertEndpoints(t, fb, "/api/page/set-http-credentials")
+	assertBody(t, call, "username", "admin")
+	assertBody(t, call, "password", "secret")
+}
+
+func TestNavigateWithResponse(t *testing.T) {
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{
+			"status":  403,
+			"url":     "https://example.com/blocked",
+			"headers": map[string]any{"server": "cloudflare"},
+		}, nil
+	})
+
+	resp, err := hc.NavigateWithResponse("https://example.com")
+	if err != nil {
+		t.Fatalf("NavigateWithResponse: %v", err)
+	}
+	if resp.Status != 403 {
+		t.Errorf("Status = %d, want 403", resp.Status)
+	}
+	if resp.URL != "https://example.com/blocked" {
+		t.Errorf("URL = %q", resp.URL)
+	}
+	if resp.Headers["server"] != "cloudflare" {
+		t.Errorf("Headers = %v", resp.Headers)
+	}
+}
+
+func TestAllInnerTextsAndTextContents(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/element/all-text-contents" {
+			return map[string]any{"texts": []any{"  Hidden\n  text "}}, nil
+		}
+		return map[string]any{"texts": []any{"text"}}, nil
+	})
+
+	inner, err := hc.ElementAllInnerTexts("p")
+	if err != nil {
+		t.Fatalf("ElementAllInnerTexts: %v", err)
+	}
+	content, err := hc.ElementAllTextContents("p")
+	if err != nil {
+		t.Fatalf("ElementAllTextContents: %v", err)
+	}
+	if _, err := hc.ElementAllTexts("p"); err != nil {
+		t.Fatalf("ElementAllTexts: %v", err)
+	}
+
+	if !reflect.DeepEqual(inner, []string{"text"}) || !reflect.DeepEqual(content, []string{"  Hidden\n  text "}) {
+		t.Errorf("inner = %q, content = %q", inner, content)
+	}
+	assertEndpoints(t, fb, "/api/element/all-texts", "/api/element/all-text-contents", "/api/element/all-texts")
+}
+
+func TestRandomWait(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.RandomWait(100, 200); err != nil {
+		t.Fatalf("RandomWait: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "min", 100)
+	assertBody(t, call, "max", 200)
+
+	if err := hc.RandomWaitTier("middle"); err != nil {
+		t.Fatalf("RandomWaitTier: %v", err)
+	}
+	call = fb.lastCall(t)
+	assertBody(t, call, "min", 1500)
+	assertBody(t, call, "max", 3000)
+
+	if err := hc.RandomWait(200, 100); err == nil {
+		t.Error("RandomWait: expected error for max < min")
+	}
+	if err := hc.RandomWaitTier("forever"); err == nil {
+		t.Error("RandomWaitTier: expected error for unknown tier")
+	}
+	if n := len(fb.Calls()); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
+
+func TestEvalOnAll(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"result": []any{"1", "2"}}, nil
+	})
+
+	result, err := hc.EvalOnAll(".job", "els => els.map(e => e.dataset.id)")
+	if err != nil {
+		t.Fatalf("EvalOnAll: %v", err)
+	}
+	if !reflect.DeepEqual(result, []any{"1", "2"}) {
+		t.Errorf("result = %v", result)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selector", ".job")
+	assertBody(t, call, "script", "els => els.map(e => e.dataset.id)")
+}
+
+func TestHARAndTracing(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+	fb.binary = func(call fakeCall) ([]byte, error) {
+		switch call.Endpoint {
+		case "/api/page/har/stop", "/api/page/tracing/stop":
+			return []byte(`{"log":{}}`), nil
+		}
+		return nil, nil
+	}
+
+	if err := hc.StartHARRecording(); err != nil {
+		t.Fatalf("StartHARRecording: %v", err)
+	}
+	har, err := hc.StopHARRecording()
+	if err != nil {
+		t.Fatalf("StopHARRecording: %v", err)
+	}
+	if string(har) != `{"log":{}}` {
+		t.Errorf("har = %s", har)
+	}
+
+	if err := hc.StartTracing(nil); err != nil {
+		t.Fatalf("StartTracing: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "categories", defaultTracingCategories)
+	trace, err := hc.StopTracing()
+	if err != nil {
+		t.Fatalf("StopTracing: %v", err)
+	}
+	if len(trace) == 0 {
+		t.Error("StopTracing returned no data")
+	}
+
+	assertEndpoints(t, fb,
+		"/api/page/har/start",
+		"/api/page/har/stop",
+		"/api/page/tracing/start",
+		"/api/page/tracing/stop",
+	)
+}
+
+func TestEmulateNetworkPreset(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.EmulateNetworkPreset("Slow 3G"); err != nil {
+		t.Fatalf("EmulateNetworkPreset: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "offline", false)
+	assertBody(t, call, "downloadKbps", 400)
+	assertBody(t, call, "uploadKbps", 400)
+	assertBody(t, call, "latencyMs", 2000)
+
+	if _, err := NetworkPreset("5G"); err == nil {
+		t.Error("NetworkPreset: expected error for unknown preset")
+	}
+	if err := hc.EmulateCPU(0.5); err == nil {
+		t.Error("EmulateCPU: expected error for rate < 1")
+	}
+	if err := hc.EmulateCPU(4); err != nil {
+		t.Fatalf("EmulateCPU: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "rate", 4)
+}
+
+func TestElementWaitFor(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.ElementWaitFor("#dialog", "detached", 0); err != nil {
+		t.Fatalf("ElementWaitFor: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "state", "detached")
+	assertNoBodyKey(t, call, "timeout")
+
+	if err := hc.ElementWaitFor("#dialog", "gone", 0); err == nil {
+		t.Error("ElementWaitFor: expected error for invalid state")
+	}
+}
+
+func TestElementDropFiles(t *testing.T) {
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "resume.pdf")
+	bin := filepath.Join(dir, "data.unknownext")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.4"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bin, []byte{0, 1, 2}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hc, fb := newFakeClient(t, nil)
+	if err := hc.ElementDropFiles("#dropzone", pdf, bin); err != nil {
+		t.Fatalf("ElementDropFiles: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "files", []map[string]any{
+		{"name": "resume.pdf", "mimeType": "application/pdf", "buffer": base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))},
+		{"name": "data.unknownext", "mimeType": "application/octet-stream", "buffer": base64.StdEncoding.EncodeToString([]byte{0, 1, 2})},
+	})
+
+	if err := hc.ElementDropFiles("#dropzone", filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("ElementDropFiles: expected error for missing file")
+	}
+	if n := len(fb.Calls()); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
+
+func TestElementAllProperties(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"properties": []any{
+			map[string]any{"text": "Home", "href": "/"},
+			map[string]any{"text": "Docs"},
+		}}, nil
+	})
+
+	props, err := hc.ElementAllProperties("nav a", "text", "href")
+	if err != nil {
+		t.Fatalf("ElementAllProperties: %v", err)
+	}
+	want := []map[string]string{
+		{"text": "Home", "href": "/"},
+		{"text": "Docs"},
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("properties = %v, want %v", props, want)
+	}
+	assertBody(t, fb.lastCall(t), "attributes", []string{"text", "href"})
+}
+
+func TestWaitForSelectorHidden(t *testing.T) {
+	// 模拟服务端：元素从 DOM 移除或不可见都视为隐藏
+	visible := map[string]bool{"#spinner": false, "#toast": true}
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		selector, _ := call.Body["selector"].(string)
+		if visible[selector] {
+			return nil, errors.New("timeout waiting for selector to be hidden")
+		}
+		return nil, nil
+	})
+
+	for _, selector := range []string{"#spinner", "#removed"} {
+		if err := hc.WaitForSelectorHidden(selector, time.Second); err != nil {
+			t.Errorf("WaitForSelectorHidden(%q): %v", selector, err)
+		}
+	}
+	if err := hc.WaitForSelectorHidden("#toast", time.Second); err == nil {
+		t.Error("WaitForSelectorHidden: expected error for visible element")
+	}
+
+	call := fb.lastCall(t)
+	if call.Endpoint != "/api/page/wait-for-selector-hidden" {
+		t.Errorf("endpoint = %s", call.Endpoint)
+	}
+	assertBody(t, call, "timeout", 1000)
+
+	if err := hc.WaitForSelectorHidden("#spinner", 0); err != nil {
+		t.Fatalf("WaitForSelectorHidden: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "timeout")
+}
+
+func TestNavigateWithOptions(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	err := hc.NavigateWithOptions("https://example.com", NavigateOptions{
+		Referer:   "https://google.com",
+		WaitUntil: "networkidle",
+		Timeout:   5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NavigateWithOptions: %v", err)
+	}
+
+	call := fb.lastCall(t)
+	assertBody(t, call, "referer", "https://google.com")
+	assertBody(t, call, "waitUntil", "networkidle")
+	assertBody(t, call, "timeout", 5000)
+
+	if err := hc.NavigateWithOptions("https://example.com", NavigateOptions{}); err != nil {
+		t.Fatalf("NavigateWithOptions: %v", err)
+	}
+	call = fb.lastCall(t)
+	assertNoBodyKey(t, call, "referer")
+	assertNoBodyKey(t, call, "waitUntil")
+	assertNoBodyKey(t, call, "timeout")
+
+	if err := hc.NavigateWithOptions("https://example.com", NavigateOptions{WaitUntil: "idle"}); err == nil {
+		t.Error("NavigateWithOptions: expected error for invalid waitUntil")
+	}
+}
+
+func TestElementWaitWithInterval(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if err := hc.ElementWaitWithInterval("#result", 5*time.Second, 50*time.Millisecond); err != nil {
+		t.Fatalf("ElementWaitWithInterval: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "interval", 50)
+	assertBody(t, call, "timeout", 5000)
+
+	if err := hc.ElementWaitWithInterval("#result", 5*time.Second, 0); err != nil {
+		t.Fatalf("ElementWaitWithInterval: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "interval", defaultPollInterval.Milliseconds())
+}
+
+func TestMatchLimitServerIgnoresLimit(t *testing.T) {
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"attributes": []any{"/a", "/b", "/c"}}, nil
+	})
+	hc.SetMaxMatches(2)
+
+	if _, err := hc.ElementAllAttributes("a", "href"); !errors.Is(err, ErrTooManyMatches) {
+		t.Fatalf("ElementAllAttributes error = %v, want ErrTooManyMatches", err)
+	}
+
+	hc.SetTruncateMatches(true)
+	attrs, err := hc.ElementAllAttributes("a", "href")
+	if err != nil {
+		t.Fatalf("ElementAllAttributes: %v", err)
+	}
+	if !reflect.DeepEqual(attrs, []string{"/a", "/b"}) {
+		t.Errorf("attributes = %v", attrs)
+	}
+}
+
+func TestNoMatchLimitByDefault(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"texts": []any{"a"}}, nil
+	})
+
+	if _, err := hc.ElementAllTexts(".item"); err != nil {
+		t.Fatalf("ElementAllTexts: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "limit")
+}
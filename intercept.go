@@ -0,0 +1,135 @@
+package cdpsdk
+
+import (
+	"fmt"
+)
+
+// 拦截动作
+const (
+	InterceptActionBlock    = "block"    // 阻止请求
+	InterceptActionContinue = "continue" // 放行请求
+	InterceptActionFulfill  = "fulfill"  // 使用预设响应
+	InterceptActionOverride = "override" // 修改请求头或请求体后放行
+)
+
+// InterceptRule 请求拦截规则
+type InterceptRule struct {
+	URLPattern string           `json:"urlPattern"`          // URL glob，如 "*.png"、"**/analytics/**"
+	Action     string           `json:"action"`              // block、continue、fulfill 或 override
+	Response   *FulfillResponse `json:"response,omitempty"`  // Action 为 fulfill 时返回的响应
+	Overrides  *RequestOverride `json:"overrides,omitempty"` // Action 为 override 时对请求的修改
+}
+
+// RequestOverride 放行前对请求的修改
+type RequestOverride struct {
+	Headers map[string]string `json:"headers,omitempty"` // 追加或覆盖的请求头，如注入 Authorization
+	Body    string            `json:"body,omitempty"`    // 替换的请求体，为空时保持原请求体
+}
+
+// FulfillResponse 拦截后返回的预设响应
+type FulfillResponse struct {
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
+	Body        string            `json:"body,omitempty"`
+}
+
+// InterceptedResponse 捕获到的网络响应
+type InterceptedResponse struct {
+	URL     string
+	Status  int
+	Headers map[string]string
+	Body    string
+}
+
+// parseInterceptedResponse 从响应数据中解析捕获到的网络响应
+func parseInterceptedResponse(data map[string]any) *InterceptedResponse {
+	resp := &InterceptedResponse{
+		Headers: make(map[string]string),
+	}
+	resp.URL, _ = data["url"].(string)
+	resp.Body, _ = data["body"].(string)
+	if status, ok := data["status"].(float64); ok {
+		resp.Status = int(status)
+	}
+	if headers, ok := data["headers"].(map[string]any); ok {
+		for k, v := range headers {
+			if s, ok := v.(string); ok {
+				resp.Headers[k] = s
+			}
+		}
+	}
+	return resp
+}
+
+// SetRequestInterception 设置请求拦截规则，按顺序匹配，覆盖之前设置的规则
+func (hc *HTTPClient) SetRequestInterception(rules []InterceptRule) error {
+	for _, rule := range rules {
+		switch rule.Action {
+		case InterceptActionBlock, InterceptActionContinue:
+		case InterceptActionFulfill:
+			if rule.Response == nil {
+				return fmt.Errorf("intercept rule %q: fulfill action requires a response", rule.URLPattern)
+			}
+		case InterceptActionOverride:
+			if rule.Overrides == nil {
+				return fmt.Errorf("intercept rule %q: override action requires overrides", rule.URLPattern)
+			}
+		default:
+			return fmt.Errorf("intercept rule %q: unsupported action %q", rule.URLPattern, rule.Action)
+		}
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"rules":     rules,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/intercept", body)
+	return err
+}
+
+// RemoveRequestInterception 清除所有请求拦截规则
+func (hc *HTTPClient) RemoveRequestInterception() error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/intercept/remove", body)
+	return err
+}
+
+// MockResponse 路由返回的模拟响应
+type MockResponse struct {
+	Status      int               `json:"status"` // 为 0 时返回 200
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        []byte            `json:"body,omitempty"` // 序列化为 base64，可包含二进制内容
+	ContentType string            `json:"contentType,omitempty"`
+}
+
+// Route 对匹配 urlPattern 的请求直接返回模拟响应，不再发往服务器，用于测试中固定 XHR 返回
+func (hc *HTTPClient) Route(urlPattern string, response MockResponse) error {
+	if response.Status == 0 {
+		response.Status = 200
+	}
+
+	body := map[string]any{
+		"sessionId":  hc.sessionId,
+		"urlPattern": urlPattern,
+		"response":   response,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/route", body)
+	return err
+}
+
+// Unroute 移除 urlPattern 对应的路由
+func (hc *HTTPClient) Unroute(urlPattern string) error {
+	body := map[string]any{
+		"sessionId":  hc.sessionId,
+		"urlPattern": urlPattern,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/unroute", body)
+	return err
+}
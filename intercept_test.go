@@ -0,0 +1,89 @@
+package cdpsdk
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestSetRequestInterception(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	rules := []InterceptRule{
+		{URLPattern: "*.png", Action: InterceptActionBlock},
+		{URLPattern: "**/api/**", Action: InterceptActionContinue},
+		{
+			URLPattern: "**/config.json",
+			Action:     InterceptActionFulfill,
+			Response:   &FulfillResponse{Status: 200, ContentType: "application/json", Body: `{"debug":true}`},
+		},
+		{
+			URLPattern: "**/graphql",
+			Action:     InterceptActionOverride,
+			Overrides:  &RequestOverride{Headers: map[string]string{"Authorization": "Bearer token"}},
+		},
+	}
+	if err := hc.SetRequestInterception(rules); err != nil {
+		t.Fatalf("SetRequestInterception: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "rules", []map[string]any{
+		{"urlPattern": "*.png", "action": "block"},
+		{"urlPattern": "**/api/**", "action": "continue"},
+		{"urlPattern": "**/config.json", "action": "fulfill", "response": map[string]any{
+			"status": 200, "contentType": "application/json", "body": `{"debug":true}`,
+		}},
+		{"urlPattern": "**/graphql", "action": "override", "overrides": map[string]any{
+			"headers": map[string]string{"Authorization": "Bearer token"},
+		}},
+	})
+
+	if err := hc.RemoveRequestInterception(); err != nil {
+		t.Fatalf("RemoveRequestInterception: %v", err)
+	}
+	assertEndpoints(t, fb, "/api/page/intercept", "/api/page/intercept/remove")
+}
+
+func TestSetRequestInterceptionValidation(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	invalid := []InterceptRule{
+		{URLPattern: "*", Action: "drop"},
+		{URLPattern: "*", Action: InterceptActionFulfill},
+		{URLPattern: "*", Action: InterceptActionOverride},
+	}
+	for _, rule := range invalid {
+		if err := hc.SetRequestInterception([]InterceptRule{rule}); err == nil {
+			t.Errorf("SetRequestInterception(%+v): expected error", rule)
+		}
+	}
+	if n := len(fb.Calls()); n != 0 {
+		t.Errorf("sent %d requests for invalid rules", n)
+	}
+}
+
+func TestRoute(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	err := hc.Route("**/api/jobs", MockResponse{
+		ContentType: "application/json",
+		Body:        []byte(`{"jobs":[]}`),
+	})
+	if err != nil {
+		t.Fatalf("Route: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "urlPattern", "**/api/jobs")
+	assertBody(t, call, "response", map[string]any{
+		"status":      200,
+		"contentType": "application/json",
+		"body":        base64.StdEncoding.EncodeToString([]byte(`{"jobs":[]}`)),
+	})
+
+	if err := hc.Unroute("**/api/jobs"); err != nil {
+		t.Fatalf("Unroute: %v", err)
+	}
+	call = fb.lastCall(t)
+	if call.Endpoint != "/api/page/unroute" {
+		t.Errorf("endpoint = %s", call.Endpoint)
+	}
+	assertBody(t, call, "urlPattern", "**/api/jobs")
+}
@@ -0,0 +1,67 @@
+package cdpsdk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSHandle 服务端保存的 JavaScript 对象引用（DOM 元素、大对象等），值本身不回传客户端
+// 可直接作为 Evaluate 的参数传入，服务端会替换为引用的对象；使用完毕后应调用 Dispose 释放
+type JSHandle struct {
+	client *HTTPClient
+	id     string
+}
+
+// EvaluateHandle 执行 JavaScript 函数体并返回结果的引用
+func (hc *HTTPClient) EvaluateHandle(script string, args ...any) (*JSHandle, error) {
+	if args == nil {
+		args = []any{}
+	}
+
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"script":    script,
+		"args":      args,
+	}
+
+	resp, err := hc.doRequest("POST", "/api/page/evaluate-handle", body)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := resp.Data["handleId"].(string)
+	if !ok {
+		return nil, fmt.Errorf("handleId not found in response")
+	}
+
+	return &JSHandle{
+		client: hc,
+		id:     id,
+	}, nil
+}
+
+// ID 获取对象引用 ID
+func (h *JSHandle) ID() string {
+	return h.id
+}
+
+// MarshalJSON 作为 Evaluate 参数时序列化为 {"jsHandleId": id}，由服务端解析为引用的对象
+func (h *JSHandle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"jsHandleId": h.id})
+}
+
+// Dispose 释放对象引用
+func (h *JSHandle) Dispose() error {
+	body := map[string]any{
+		"sessionId": h.client.sessionId,
+		"handleId":  h.id,
+	}
+
+	_, err := h.client.doRequest("POST", "/api/js-handle/dispose", body)
+	return err
+}
+
+// EvaluateHandle 执行 JavaScript 函数体并返回结果的引用，可作为后续 Evaluate 的参数
+func (p *Page) EvaluateHandle(script string, args ...any) (*JSHandle, error) {
+	return p.client.EvaluateHandle(script, args...)
+}
@@ -0,0 +1,39 @@
+package cdpsdk
+
+import "testing"
+
+func TestJSHandle(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/page/evaluate-handle":
+			return map[string]any{"handleId": "js1"}, nil
+		case "/api/page/evaluate":
+			return map[string]any{"result": 3}, nil
+		}
+		return nil, nil
+	})
+
+	handle, err := page.EvaluateHandle("return document.querySelectorAll(arguments[0])", "li")
+	if err != nil {
+		t.Fatalf("EvaluateHandle: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "args", []any{"li"})
+
+	result, err := page.Evaluate("return arguments[0].length", handle)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if result != float64(3) {
+		t.Errorf("result = %v", result)
+	}
+	assertBody(t, fb.lastCall(t), "args", []any{map[string]any{"jsHandleId": "js1"}})
+
+	if err := handle.Dispose(); err != nil {
+		t.Fatalf("Dispose: %v", err)
+	}
+	call := fb.lastCall(t)
+	if call.Endpoint != "/api/js-handle/dispose" {
+		t.Errorf("endpoint = %s", call.Endpoint)
+	}
+	assertBody(t, call, "handleId", "js1")
+}
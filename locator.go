@@ -1,24 +1,141 @@
 package cdpsdk
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"time"
 )
 
 // Locator 元素定位器，支持链式调用
 type Locator struct {
 	client    *HTTPClient
 	selector  string
-	selectors []string // 选择器链
+	selectors []string       // 选择器链
+	query     map[string]any // 附加定位参数（如 nth），随元素请求一并发送
+	retries   int            // 元素失效时 Click、SetValue、Text 的重试次数
+	page      *Page          // 所属页面，等待操作在调用时读取其默认超时
 }
 
 // Locator 嵌套定位器，支持多级定位
+// 当前定位器带有附加定位参数时，以 parent 字段交由服务端先定位父元素
 func (l *Locator) Locator(selector string) *Locator {
+	selectors := append(append([]string{}, l.selectors...), selector)
+	if len(l.query) > 0 {
+		return &Locator{
+			client:    l.client,
+			selector:  selector,
+			selectors: selectors,
+			query:     map[string]any{"parent": l.target()},
+			retries:   l.retries,
+			page:      l.page,
+		}
+	}
+
 	newSelector := fmt.Sprintf("%s %s", l.selector, selector)
 	return &Locator{
 		client:    l.client,
 		selector:  newSelector,
-		selectors: append(l.selectors, selector),
+		selectors: selectors,
+		retries:   l.retries,
+		page:      l.page,
+	}
+}
+
+// clone 复制定位器
+func (l *Locator) clone() *Locator {
+	return &Locator{
+		client:    l.client,
+		selector:  l.selector,
+		selectors: l.selectors,
+		query:     maps.Clone(l.query),
+		retries:   l.retries,
+		page:      l.page,
+	}
+}
+
+// defaultTimeout 返回所属页面当前的默认超时，SetDefaultTimeout 对已创建的定位器同样生效
+func (l *Locator) defaultTimeout() time.Duration {
+	if l.page == nil {
+		return 0
 	}
+	return l.page.timeout
+}
+
+// pollTimeout 返回客户端轮询的超时，timeout 为 0 时使用页面默认超时，均未设置时为 defaultWaitTimeout
+func (l *Locator) pollTimeout(timeout time.Duration) time.Duration {
+	if timeout == 0 {
+		timeout = l.defaultTimeout()
+	}
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	return timeout
+}
+
+// with 复制定位器并追加定位参数
+func (l *Locator) with(key string, value any) *Locator {
+	locator := l.clone()
+	if locator.query == nil {
+		locator.query = make(map[string]any)
+	}
+	locator.query[key] = value
+	return locator
+}
+
+// target 返回定位参数（不含 sessionId）
+func (l *Locator) target() map[string]any {
+	target := map[string]any{
+		"selector": l.selector,
+	}
+	maps.Copy(target, l.query)
+	return target
+}
+
+// body 构造元素请求体
+func (l *Locator) body() map[string]any {
+	body := l.client.selectorBody(l.selector)
+	maps.Copy(body, l.query)
+	return body
+}
+
+// defaultLocatorRetries Page.Locator 创建的定位器在元素失效时的默认重试次数
+const defaultLocatorRetries = 1
+
+// WithRetry 返回在元素未找到或已从 DOM 分离时自动重试 n 次的定位器，Page.Locator 创建的定位器默认重试 1 次，n 为 0 时不重试
+// 仅作用于 Click、SetValue、Text，每次重试都会重新查询元素；Exists 不重试
+func (l *Locator) WithRetry(n int) *Locator {
+	locator := l.clone()
+	locator.retries = n
+	return locator
+}
+
+// staleElementErrors 服务端返回的元素失效错误关键字
+var staleElementErrors = []string{"not found", "detached", "not attached", "stale"}
+
+// isStaleElementError 判断是否为元素未找到或已失效的服务端错误
+func isStaleElementError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	if !strings.HasPrefix(msg, "server error:") {
+		return false
+	}
+	for _, keyword := range staleElementErrors {
+		if strings.Contains(msg, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry 执行 fn，元素失效时按 retries 重试
+func (l *Locator) retry(fn func() error) error {
+	err := fn()
+	for i := 0; i < l.retries && err != nil && isStaleElementError(err); i++ {
+		err = fn()
+	}
+	return err
 }
 
 // GetSelectors 获取选择器链
@@ -31,47 +148,395 @@ func (l *Locator) GetSelector() string {
 	return l.selector
 }
 
+// Nth 定位第 index 个匹配元素（从 0 开始）
+func (l *Locator) Nth(index int) *Locator {
+	return l.with("nth", index)
+}
+
+// FilterOptions 定位器过滤条件
+type FilterOptions struct {
+	HasText    string   // 元素文本包含该内容
+	HasNotText string   // 元素文本不包含该内容
+	Has        *Locator // 元素内必须存在的后代元素
+}
+
+// Filter 按文本或后代元素过滤匹配结果，由服务端计算
+func (l *Locator) Filter(opts FilterOptions) *Locator {
+	filter := make(map[string]any)
+	if opts.HasText != "" {
+		filter["hasText"] = opts.HasText
+	}
+	if opts.HasNotText != "" {
+		filter["hasNotText"] = opts.HasNotText
+	}
+	if opts.Has != nil {
+		filter["has"] = opts.Has.target()
+	}
+
+	return l.with("filter", filter)
+}
+
+// All 获取所有匹配元素的定位器
+func (l *Locator) All() ([]*Locator, error) {
+	count, err := l.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err = l.client.limitMatches(count)
+	if err != nil {
+		return nil, err
+	}
+
+	locators := make([]*Locator, count)
+	for i := range locators {
+		locators[i] = l.Nth(i)
+	}
+	return locators, nil
+}
+
+// ForEach 按顺序遍历所有匹配元素，遇到第一个错误即停止
+func (l *Locator) ForEach(fn func(index int, l *Locator) error) error {
+	locators, err := l.All()
+	if err != nil {
+		return err
+	}
+
+	for i, locator := range locators {
+		if err := fn(i, locator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Exists 检查元素是否存在
 func (l *Locator) Exists() (bool, error) {
-	return l.client.ElementExists(l.selector)
+	return l.client.elementExists(l.body())
 }
 
 // Text 获取元素文本
 func (l *Locator) Text() (string, error) {
-	return l.client.ElementText(l.selector)
+	var text string
+	err := l.retry(func() error {
+		var err error
+		text, err = l.client.elementText(l.body())
+		return err
+	})
+	return text, err
+}
+
+// InnerText 获取元素渲染后的文本（innerText）
+func (l *Locator) InnerText() (string, error) {
+	return l.client.innerText(l.body())
+}
+
+// TextContent 获取元素原始文本（textContent）
+func (l *Locator) TextContent() (string, error) {
+	return l.client.textContent(l.body())
+}
+
+// BestText 依次尝试 innerText、textContent、value 属性、placeholder 属性，返回第一个非空文本
+func (l *Locator) BestText() (string, error) {
+	text, err := l.InnerText()
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(text) != "" {
+		return text, nil
+	}
+
+	text, err = l.TextContent()
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(text) != "" {
+		return text, nil
+	}
+
+	// 属性不存在是正常情况，忽略错误继续尝试
+	for _, attr := range []string{"value", "placeholder"} {
+		if value, err := l.Attribute(attr); err == nil && value != "" {
+			return value, nil
+		}
+	}
+
+	return "", nil
 }
 
 // Click 点击元素
 func (l *Locator) Click() error {
-	return l.client.ElementClick(l.selector)
+	return l.retry(func() error {
+		return l.client.elementClick(l.body())
+	})
+}
+
+// WaitFor 等待元素达到指定状态：attached、detached、visible、hidden
+// timeout 为 0 时使用 Page.SetDefaultTimeout 设置的超时，均未设置时使用服务端默认超时
+func (l *Locator) WaitFor(state string, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = l.defaultTimeout()
+	}
+	return l.client.elementWaitFor(l.body(), state, timeout)
+}
+
+// ClickWhenVisible 等待元素可见后点击，timeout 为 0 时使用 Page.SetDefaultTimeout 设置的超时
+func (l *Locator) ClickWhenVisible(timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = l.defaultTimeout()
+	}
+	if err := l.WaitFor("visible", timeout); err != nil {
+		if timeout > 0 {
+			return fmt.Errorf("element %s not visible within %s: %w", l.selector, timeout, err)
+		}
+		return fmt.Errorf("element %s not visible: %w", l.selector, err)
+	}
+	return l.Click()
+}
+
+// ClickWithOptions 按选项点击元素（按键、次数、修饰键、位置、延迟）
+func (l *Locator) ClickWithOptions(opts ClickOptions) error {
+	return l.client.elementClickWithOptions(l.body(), opts)
+}
+
+// DoubleClick 双击元素
+func (l *Locator) DoubleClick() error {
+	return l.client.elementClickWithOptions(l.body(), ClickOptions{ClickCount: 2})
+}
+
+// ClickRight 右键点击元素
+func (l *Locator) ClickRight() error {
+	return l.client.elementClickWithOptions(l.body(), ClickOptions{Button: "right"})
+}
+
+// ClickAndWaitForResponse 点击元素并等待 URL 匹配 urlPattern 的响应，timeoutMs 为毫秒
+func (l *Locator) ClickAndWaitForResponse(urlPattern string, timeoutMs int) (*InterceptedResponse, error) {
+	return l.client.elementClickAndWaitForResponse(l.body(), urlPattern, timeoutMs)
 }
 
 // Hover 鼠标悬停
 func (l *Locator) Hover() error {
-	return l.client.ElementHover(l.selector)
+	return l.client.elementHover(l.body())
+}
+
+// HoverWithOptions 悬停在相对元素左上角的 (x, y) 位置
+func (l *Locator) HoverWithOptions(x, y float64) error {
+	body := l.body()
+	body["position"] = map[string]any{
+		"x": x,
+		"y": y,
+	}
+	return l.client.elementHover(body)
+}
+
+// HoverThenClick 悬停在当前元素上，等待 delay 后点击 target，用于点击悬停后才出现的菜单项
+func (l *Locator) HoverThenClick(target *Locator, delay time.Duration) error {
+	if err := l.Hover(); err != nil {
+		return err
+	}
+	time.Sleep(delay)
+	return target.Click()
+}
+
+// Focus 聚焦元素并触发 focus 事件
+func (l *Locator) Focus() error {
+	return l.client.elementFocus(l.body())
+}
+
+// Blur 使元素失去焦点并触发 blur 事件
+func (l *Locator) Blur() error {
+	return l.client.elementBlur(l.body())
+}
+
+// DragTo 将元素拖放到目标元素中心（源元素按下、移动到目标中心、松开）
+func (l *Locator) DragTo(target *Locator) error {
+	if target == nil {
+		return fmt.Errorf("drag target is nil")
+	}
+	return l.client.elementDragTo(l.body(), target.target())
+}
+
+// ScrollIntoView 将元素滚动到可见区域
+func (l *Locator) ScrollIntoView() error {
+	return l.client.elementScrollIntoView(l.body())
+}
+
+// ScrollBy 按像素偏移 (dx, dy) 滚动可滚动的容器元素
+func (l *Locator) ScrollBy(dx, dy int) error {
+	return l.client.elementScrollBy(l.body(), dx, dy)
+}
+
+// DropFiles 模拟将本地文件拖放到元素上，用于没有 <input type=file> 的上传控件
+func (l *Locator) DropFiles(paths ...string) error {
+	return l.client.elementDropFiles(l.body(), paths...)
 }
 
 // SetValue 设置元素值
 func (l *Locator) SetValue(value string) error {
-	return l.client.ElementSetValue(l.selector, value)
+	return l.retry(func() error {
+		return l.client.elementSetValue(l.body(), value)
+	})
 }
 
 // Attribute 获取元素属性
 func (l *Locator) Attribute(attr string) (string, error) {
-	return l.client.ElementAttribute(l.selector, attr)
+	return l.client.elementAttribute(l.body(), attr)
+}
+
+// InnerHTML 获取元素内部 HTML
+func (l *Locator) InnerHTML() (string, error) {
+	return l.client.elementInnerHTML(l.body())
+}
+
+// GetProperty 获取元素 DOM 属性（如 value、checked），值为 string、bool、float64 等 JSON 类型
+func (l *Locator) GetProperty(name string) (any, error) {
+	return l.client.elementProperty(l.body(), name)
 }
 
-// AllTexts 获取所有匹配元素的文本
+// AllTexts 获取所有匹配元素的文本，等同于 AllInnerTexts
 func (l *Locator) AllTexts() ([]string, error) {
-	return l.client.ElementAllTexts(l.selector)
+	return l.AllInnerTexts()
+}
+
+// AllInnerTexts 获取所有匹配元素渲染后的文本（innerText，不含隐藏节点，空白按布局折叠）
+func (l *Locator) AllInnerTexts() ([]string, error) {
+	return l.client.elementAllTexts(l.body(), "/api/element/all-texts")
+}
+
+// AllTextContents 获取所有匹配元素的原始文本（textContent，包含隐藏节点和原始空白）
+func (l *Locator) AllTextContents() ([]string, error) {
+	return l.client.elementAllTexts(l.body(), "/api/element/all-text-contents")
 }
 
 // AllAttributes 获取所有匹配元素的属性
 func (l *Locator) AllAttributes(attr string) ([]string, error) {
-	return l.client.ElementAllAttributes(l.selector, attr)
+	return l.client.elementAllAttributes(l.body(), attr)
+}
+
+// AllProperties 一次请求获取所有匹配元素的多个属性，每个元素返回一个属性名到属性值的映射
+// 属性名 text 表示元素文本
+func (l *Locator) AllProperties(attrs ...string) ([]map[string]string, error) {
+	return l.client.elementAllProperties(l.body(), attrs)
 }
 
 // Count 获取元素数量
 func (l *Locator) Count() (int, error) {
-	return l.client.ElementCount(l.selector)
+	return l.client.elementCount(l.body())
+}
+
+// CountByAttribute 按属性值分组统计匹配元素数量
+func (l *Locator) CountByAttribute(attr string) (map[string]int, error) {
+	return l.client.elementCountByAttribute(l.body(), attr)
+}
+
+// DOMTree 获取元素的 DOM 子树（tag、attributes、children），maxDepth 限制层级深度
+func (l *Locator) DOMTree(maxDepth int) (map[string]any, error) {
+	return l.client.elementDOMTree(l.body(), maxDepth)
+}
+
+// Screenshot 元素截图
+func (l *Locator) Screenshot(format string) ([]byte, error) {
+	return l.client.elementScreenshot(l.body(), format)
+}
+
+// HoverThenScreenshot 悬停后截图，用于捕获 hover 状态
+func (l *Locator) HoverThenScreenshot(format string) ([]byte, error) {
+	if err := l.Hover(); err != nil {
+		return nil, err
+	}
+	return l.Screenshot(format)
+}
+
+// BoundingBox 获取元素位置和尺寸，元素未渲染时返回 ErrElementNotRendered
+func (l *Locator) BoundingBox() (*Rect, error) {
+	return l.client.elementBoundingBox(l.body())
+}
+
+// WaitForText 等待元素文本等于 expected，timeoutMs 为毫秒，为 0 时使用页面默认超时
+func (l *Locator) WaitForText(expected string, timeoutMs int) error {
+	return l.waitForText(expected, timeoutMs, func(text string) bool {
+		return text == expected
+	})
+}
+
+// WaitForTextContains 等待元素文本包含 substr，timeoutMs 为毫秒，为 0 时使用页面默认超时
+func (l *Locator) WaitForTextContains(substr string, timeoutMs int) error {
+	return l.waitForText(substr, timeoutMs, func(text string) bool {
+		return strings.Contains(text, substr)
+	})
+}
+
+func (l *Locator) waitForText(expected string, timeoutMs int, match func(string) bool) error {
+	var last string
+	err := poll(context.Background(), l.pollTimeout(time.Duration(timeoutMs)*time.Millisecond), defaultPollInterval, func() (bool, error) {
+		text, err := l.Text()
+		if err != nil {
+			return false, err
+		}
+		last = text
+		return match(strings.TrimSpace(text)), nil
+	})
+	if err == ErrTimeout {
+		return fmt.Errorf("wait for text %q on %s (last %q): %w", expected, l.selector, last, err)
+	}
+	return err
+}
+
+// WaitForAttributePresent 等待元素出现 attr 属性并返回其值，timeoutMs 为毫秒，为 0 时使用页面默认超时
+// 轮询期间元素或属性不存在都视为尚未就绪
+func (l *Locator) WaitForAttributePresent(attr string, timeoutMs int) (string, error) {
+	var value string
+	var lastErr error
+	err := poll(context.Background(), l.pollTimeout(time.Duration(timeoutMs)*time.Millisecond), defaultPollInterval, func() (bool, error) {
+		v, err := l.Attribute(attr)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		value = v
+		return true, nil
+	})
+	if err == ErrTimeout {
+		if lastErr != nil {
+			return "", fmt.Errorf("wait for attribute %q on %s (last error: %v): %w", attr, l.selector, lastErr, err)
+		}
+		return "", fmt.Errorf("wait for attribute %q on %s: %w", attr, l.selector, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// TextMatches 检查元素文本是否匹配正则表达式 pattern，pattern 无效时在发送请求前返回错误
+func (l *Locator) TextMatches(pattern string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	text, err := l.Text()
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(text), nil
+}
+
+// AttributeMatches 检查元素属性值是否匹配正则表达式 pattern，pattern 无效时在发送请求前返回错误
+func (l *Locator) AttributeMatches(attr, pattern string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	value, err := l.Attribute(attr)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(value), nil
+}
+
+// EvalOnAll 对所有匹配元素执行一次 JavaScript 函数，函数接收元素数组作为参数
+func (l *Locator) EvalOnAll(script string) ([]any, error) {
+	return l.client.evalOnAll(l.body(), script)
 }
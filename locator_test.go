@@ -0,0 +1,543 @@
+package cdpsdk
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNestedLocator(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"text": "ok"}, nil
+	})
+
+	locator := page.Locator("#list").Locator(".item")
+	if got := locator.GetSelector(); got != "#list .item" {
+		t.Errorf("selector = %q", got)
+	}
+	if got := locator.GetSelectors(); !reflect.DeepEqual(got, []string{"#list", ".item"}) {
+		t.Errorf("selectors = %v", got)
+	}
+
+	// 带 nth 的父定位器交由服务端先定位父元素
+	nested := page.Locator(".card").Nth(1).Locator("h2")
+	if _, err := nested.Text(); err != nil {
+		t.Fatalf("Text: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selector", "h2")
+	assertBody(t, call, "parent", map[string]any{"selector": ".card", "nth": 1})
+}
+
+func TestLocatorNthDoesNotModifyParent(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	base := page.Locator(".item")
+	if err := base.Nth(2).Click(); err != nil {
+		t.Fatalf("Click: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "nth", 2)
+
+	if err := base.Click(); err != nil {
+		t.Fatalf("Click: %v", err)
+	}
+	assertNoBodyKey(t, fb.lastCall(t), "nth")
+}
+
+func TestForEach(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/element/count" {
+			return map[string]any{"count": 3}, nil
+		}
+		return map[string]any{"text": "row"}, nil
+	})
+
+	var indices []int
+	err := page.Locator("tr").ForEach(func(index int, l *Locator) error {
+		indices = append(indices, index)
+		_, err := l.Text()
+		return err
+	})
+	if err != nil {
+		t.Fatalf("ForEach: %v", err)
+	}
+	if !reflect.DeepEqual(indices, []int{0, 1, 2}) {
+		t.Errorf("indices = %v", indices)
+	}
+
+	calls := fb.Calls()
+	if len(calls) != 4 {
+		t.Fatalf("sent %d requests, want 4", len(calls))
+	}
+	for i, call := range calls[1:] {
+		assertBody(t, call, "nth", i)
+	}
+
+	stopErr := errors.New("stop")
+	var visited int
+	err = page.Locator("tr").ForEach(func(index int, l *Locator) error {
+		visited++
+		return stopErr
+	})
+	if !errors.Is(err, stopErr) || visited != 1 {
+		t.Errorf("ForEach error = %v after %d elements, want stop after 1", err, visited)
+	}
+}
+
+func TestHoverThenScreenshot(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+	fb.binary = func(call fakeCall) ([]byte, error) {
+		if call.Endpoint != "/api/element/screenshot" {
+			return nil, nil
+		}
+		return []byte("png"), nil
+	}
+
+	data, err := page.Locator(".tooltip-trigger").HoverThenScreenshot("png")
+	if err != nil {
+		t.Fatalf("HoverThenScreenshot: %v", err)
+	}
+	if string(data) != "png" {
+		t.Errorf("data = %q", data)
+	}
+	assertEndpoints(t, fb, "/api/element/hover", "/api/element/screenshot")
+}
+
+func TestFilter(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"count": 1}, nil
+	})
+
+	locator := page.Locator(".job").Filter(FilterOptions{
+		HasText:    "Go",
+		HasNotText: "Intern",
+		Has:        page.Locator(".salary"),
+	})
+	if _, err := locator.Count(); err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "filter", map[string]any{
+		"hasText":    "Go",
+		"hasNotText": "Intern",
+		"has":        map[string]any{"selector": ".salary"},
+	})
+}
+
+func TestGetByStrategies(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	tests := []struct {
+		locator *Locator
+		want    map[string]any
+	}{
+		{page.GetByText("Sign in", true), map[string]any{"selector": "Sign in", "strategy": "text", "exact": true}},
+		{page.GetByRole("button", "Submit"), map[string]any{"selector": "button", "strategy": "role", "name": "Submit"}},
+		{page.GetByRole("heading", ""), map[string]any{"selector": "heading", "strategy": "role"}},
+		{page.GetByTestId("login-form"), map[string]any{"selector": "login-form", "strategy": "testid"}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.locator.Click(); err != nil {
+			t.Fatalf("Click: %v", err)
+		}
+		call := fb.lastCall(t)
+		delete(call.Body, "sessionId")
+		if !reflect.DeepEqual(call.Body, jsonValue(tt.want)) {
+			t.Errorf("body = %v, want %v", call.Body, tt.want)
+		}
+	}
+}
+
+func TestLocatorDragTo(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.Locator(".card").Nth(0).DragTo(page.Locator(".column").Nth(2)); err != nil {
+		t.Fatalf("DragTo: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selector", ".card")
+	assertBody(t, call, "nth", 0)
+	assertBody(t, call, "target", map[string]any{"selector": ".column", "nth": 2})
+
+	if err := page.Locator(".card").DragTo(nil); err == nil {
+		t.Error("DragTo: expected error for nil target")
+	}
+}
+
+func TestDoubleClickAndClickRight(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.DoubleClick("#row"); err != nil {
+		t.Fatalf("DoubleClick: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "clickCount", 2)
+
+	if err := page.ClickRight("#row"); err != nil {
+		t.Fatalf("ClickRight: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "button", "right")
+}
+
+func TestWaitForText(t *testing.T) {
+	newPage := func(texts ...string) *Page {
+		var i int
+		page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+			text := texts[min(i, len(texts)-1)]
+			i++
+			return map[string]any{"text": text}, nil
+		})
+		return page
+	}
+
+	if err := newPage("Loading", " Done ").WaitForElementText("#status", "Done", 2000); err != nil {
+		t.Errorf("WaitForElementText: %v", err)
+	}
+	if err := newPage("Saved 3 items").Locator("#status").WaitForTextContains("3 items", 2000); err != nil {
+		t.Errorf("WaitForTextContains: %v", err)
+	}
+
+	err := newPage("Loading").WaitForElementText("#status", "Done", 50)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("WaitForElementText error = %v, want ErrTimeout", err)
+	}
+	if !strings.Contains(err.Error(), `last "Loading"`) {
+		t.Errorf("error %q does not include the last text", err)
+	}
+}
+
+func TestAllRespectsMaxMatches(t *testing.T) {
+	page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"count": 5}, nil
+	})
+	page.GetClient().SetMaxMatches(3)
+
+	if _, err := page.Locator("li").All(); !errors.Is(err, ErrTooManyMatches) {
+		t.Fatalf("All error = %v, want ErrTooManyMatches", err)
+	}
+
+	page.GetClient().SetTruncateMatches(true)
+	locators, err := page.Locator("li").All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(locators) != 3 {
+		t.Errorf("got %d locators, want 3", len(locators))
+	}
+}
+
+func TestBestText(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]map[string]any
+		want      string
+	}{
+		{
+			name: "inner text",
+			responses: map[string]map[string]any{
+				"/api/page/inner-text": {"text": "Visible"},
+			},
+			want: "Visible",
+		},
+		{
+			name: "text content fallback",
+			responses: map[string]map[string]any{
+				"/api/page/inner-text":        {"text": "  "},
+				"/api/page/must-text-content": {"text": "Hidden"},
+			},
+			want: "Hidden",
+		},
+		{
+			name: "placeholder fallback",
+			responses: map[string]map[string]any{
+				"/api/page/inner-text":        {"text": ""},
+				"/api/page/must-text-content": {"text": ""},
+			},
+			want: "Search jobs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+				if call.Endpoint == "/api/element/attribute" {
+					if call.Body["attribute"] == "placeholder" {
+						return map[string]any{"value": "Search jobs"}, nil
+					}
+					return nil, errors.New("attribute not found")
+				}
+				return tt.responses[call.Endpoint], nil
+			})
+
+			text, err := page.Locator("input").BestText()
+			if err != nil {
+				t.Fatalf("BestText: %v", err)
+			}
+			if text != tt.want {
+				t.Errorf("BestText = %q, want %q", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocatorUsesCurrentPageTimeout(t *testing.T) {
+	var texts, attributes int
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/element/text":
+			texts++
+			if texts < 2 {
+				return map[string]any{"text": "Loading"}, nil
+			}
+			return map[string]any{"text": "Done"}, nil
+		case "/api/element/attribute":
+			attributes++
+			if attributes < 2 {
+				return nil, errors.New("attribute not found")
+			}
+			return map[string]any{"value": "abc123"}, nil
+		}
+		return nil, nil
+	})
+
+	// 定位器创建之后设置的默认超时同样生效
+	locator := page.Locator("#status")
+	page.SetDefaultTimeout(2 * time.Second)
+
+	if err := locator.WaitFor("visible", 0); err != nil {
+		t.Fatalf("WaitFor: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", 2000)
+
+	if err := locator.WaitForText("Done", 0); err != nil {
+		t.Errorf("WaitForText: %v", err)
+	}
+
+	if _, err := locator.WaitForAttributePresent("data-token", 0); err != nil {
+		t.Errorf("WaitForAttributePresent: %v", err)
+	}
+}
+
+func TestWaitForAttributePresent(t *testing.T) {
+	var calls int
+	page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		calls++
+		if calls < 2 {
+			return nil, errors.New("attribute not found")
+		}
+		return map[string]any{"value": "abc123"}, nil
+	})
+
+	value, err := page.Locator("#token").WaitForAttributePresent("data-token", 2000)
+	if err != nil {
+		t.Fatalf("WaitForAttributePresent: %v", err)
+	}
+	if value != "abc123" {
+		t.Errorf("value = %q", value)
+	}
+
+	missing, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return nil, errors.New("attribute not found")
+	})
+	_, err = missing.Locator("#token").WaitForAttributePresent("data-token", 50)
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("WaitForAttributePresent error = %v, want ErrTimeout", err)
+	}
+}
+
+func TestStaleElementRetry(t *testing.T) {
+	// newPage 第一次请求返回元素未找到，之后成功
+	newPage := func(t *testing.T) (*Page, *fakeBackend) {
+		var calls int
+		return newFakePage(t, func(call fakeCall) (map[string]any, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("element not found")
+			}
+			return nil, nil
+		})
+	}
+
+	t.Run("default retries once", func(t *testing.T) {
+		page, fb := newPage(t)
+		if err := page.Locator("#submit").Click(); err != nil {
+			t.Fatalf("Click: %v", err)
+		}
+		if n := len(fb.Calls()); n != 2 {
+			t.Errorf("sent %d requests, want 2", n)
+		}
+	})
+
+	t.Run("retry disabled", func(t *testing.T) {
+		page, fb := newPage(t)
+		if err := page.Locator("#submit").WithRetry(0).Click(); err == nil {
+			t.Fatal("Click: expected error")
+		}
+		if n := len(fb.Calls()); n != 1 {
+			t.Errorf("sent %d requests, want 1", n)
+		}
+	})
+
+	t.Run("other errors not retried", func(t *testing.T) {
+		page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+			return nil, errors.New("element is not visible")
+		})
+		if err := page.Locator("#submit").WithRetry(3).SetValue("x"); err == nil {
+			t.Fatal("SetValue: expected error")
+		}
+		if n := len(fb.Calls()); n != 1 {
+			t.Errorf("sent %d requests, want 1", n)
+		}
+	})
+}
+
+func TestIsStaleElementError(t *testing.T) {
+	tests := []struct {
+		err  string
+		want bool
+	}{
+		{"server error: element not found", true},
+		{"server error: Node is detached from document", true},
+		{"server error: stale element reference", true},
+		{"server error: element is not visible", false},
+		{"failed to send request: host not found", false},
+	}
+
+	for _, tt := range tests {
+		if got := isStaleElementError(errors.New(tt.err)); got != tt.want {
+			t.Errorf("isStaleElementError(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestClickWhenVisible(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.Locator("#submit").ClickWhenVisible(2 * time.Second); err != nil {
+		t.Fatalf("ClickWhenVisible: %v", err)
+	}
+	assertEndpoints(t, fb, "/api/element/wait-for", "/api/element/click")
+	call := fb.Calls()[0]
+	assertBody(t, call, "state", "visible")
+	assertBody(t, call, "timeout", 2000)
+
+	hidden, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return nil, errors.New("timeout waiting for element")
+	})
+	err := hidden.Locator("#submit").ClickWhenVisible(time.Second)
+	if err == nil || !strings.Contains(err.Error(), "not visible within 1s") {
+		t.Errorf("ClickWhenVisible error = %v", err)
+	}
+	assertEndpoints(t, fb, "/api/element/wait-for")
+}
+
+func TestVisibleText(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/page/inner-text":
+			return map[string]any{"text": "Section"}, nil
+		case "/api/page/execute":
+			return map[string]any{"result": "Whole page"}, nil
+		}
+		return nil, nil
+	})
+
+	text, err := page.VisibleText("#main")
+	if err != nil || text != "Section" {
+		t.Errorf("VisibleText = %q, %v", text, err)
+	}
+	all, err := page.InnerTextAll()
+	if err != nil || all != "Whole page" {
+		t.Errorf("InnerTextAll = %q, %v", all, err)
+	}
+	assertEndpoints(t, fb, "/api/page/inner-text", "/api/page/execute")
+}
+
+func TestLocatorAny(t *testing.T) {
+	// 模拟服务端：使用第一个有匹配的候选选择器
+	existing := map[string]bool{".login-btn": true}
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		candidates, _ := call.Body["selectors"].([]any)
+		for _, c := range candidates {
+			if existing[c.(string)] {
+				return map[string]any{"text": "Log in via " + c.(string)}, nil
+			}
+		}
+		return nil, errors.New("element not found")
+	})
+
+	locator := page.LocatorAny("#login", ".login-btn", "button[type=submit]")
+	text, err := locator.Text()
+	if err != nil {
+		t.Fatalf("Text: %v", err)
+	}
+	if text != "Log in via .login-btn" {
+		t.Errorf("text = %q", text)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selector", "#login")
+	assertBody(t, call, "selectors", []string{"#login", ".login-btn", "button[type=submit]"})
+}
+
+func TestHoverThenClick(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	menu := page.Locator("#menu")
+	if err := menu.HoverThenClick(page.Locator("#menu .logout"), time.Millisecond); err != nil {
+		t.Fatalf("HoverThenClick: %v", err)
+	}
+	assertEndpoints(t, fb, "/api/element/hover", "/api/element/click")
+	calls := fb.Calls()
+	assertBody(t, calls[0], "selector", "#menu")
+	assertBody(t, calls[1], "selector", "#menu .logout")
+
+	if err := menu.HoverWithOptions(5, 8); err != nil {
+		t.Fatalf("HoverWithOptions: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "position", map[string]any{"x": 5, "y": 8})
+}
+
+func TestTextAndAttributeMatches(t *testing.T) {
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/element/attribute" {
+			return map[string]any{"value": "/jobs/12345"}, nil
+		}
+		return map[string]any{"text": "Order #4521 confirmed"}, nil
+	})
+
+	ok, err := page.Locator("#msg").TextMatches(`^Order #\d+ confirmed$`)
+	if err != nil || !ok {
+		t.Errorf("TextMatches = %v, %v", ok, err)
+	}
+	ok, err = page.Locator("a").AttributeMatches("href", `^/jobs/\d+$`)
+	if err != nil || !ok {
+		t.Errorf("AttributeMatches = %v, %v", ok, err)
+	}
+	ok, err = page.Locator("a").AttributeMatches("href", `^/users/`)
+	if err != nil || ok {
+		t.Errorf("AttributeMatches = %v, %v, want false", ok, err)
+	}
+
+	sent := len(fb.Calls())
+	if _, err := page.Locator("#msg").TextMatches(`(`); err == nil {
+		t.Error("TextMatches: expected error for invalid pattern")
+	}
+	if _, err := page.Locator("a").AttributeMatches("href", `[`); err == nil {
+		t.Error("AttributeMatches: expected error for invalid pattern")
+	}
+	if n := len(fb.Calls()); n != sent {
+		t.Errorf("invalid patterns sent %d requests", n-sent)
+	}
+}
+
+func TestLocatorScrollBy(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.Locator(".feed").ScrollBy(0, 400); err != nil {
+		t.Fatalf("ScrollBy: %v", err)
+	}
+	call := fb.lastCall(t)
+	assertBody(t, call, "selector", ".feed")
+	assertBody(t, call, "deltaY", 400)
+}
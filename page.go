@@ -2,13 +2,19 @@ package cdpsdk
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Page 页面结构体，封装页面相关操作
 type Page struct {
-	client *HTTPClient
+	client  *HTTPClient
+	timeout time.Duration // 等待操作的默认超时
 }
 
+// defaultWaitTimeout 未设置超时时 Wait 以及客户端轮询（如 WaitForCount）使用的超时
+const defaultWaitTimeout = 10 * time.Second
+
 // NewPage 创建页面实例
 func NewPage(client *HTTPClient) *Page {
 	return &Page{
@@ -16,6 +22,41 @@ func NewPage(client *HTTPClient) *Page {
 	}
 }
 
+// SetDefaultTimeout 设置等待操作的默认超时，作用于 Wait、WaitForSelectorVisible、WaitForSelectorHidden 以及由此页面创建的 Locator 的 WaitFor
+// 未设置或设置为 0 时恢复各操作原有的默认值：服务端等待使用服务端默认超时，Wait 和客户端轮询为 10 秒
+func (p *Page) SetDefaultTimeout(d time.Duration) {
+	p.timeout = d
+}
+
+// NewPageWithURL 在默认上下文中打开新页面并导航到 url，waitForLoad 为 true 时等待加载完成
+// 新页面使用独立的会话 ID，导航失败时关闭该页面并返回错误
+func (hc *HTTPClient) NewPageWithURL(url string, waitForLoad bool) (*Page, error) {
+	// 先校验 URL，避免为无效 URL 创建页面
+	if _, err := hc.normalizeURL(url); err != nil {
+		return nil, err
+	}
+
+	pageClient, err := hc.newPageClient("")
+	if err != nil {
+		return nil, err
+	}
+	page := NewPage(pageClient)
+
+	navigate := page.Navigate
+	if waitForLoad {
+		navigate = page.NavigateWithLoadedState
+	}
+
+	if err := navigate(url); err != nil {
+		if closeErr := page.Close(); closeErr != nil {
+			return nil, fmt.Errorf("%w (close page: %v)", err, closeErr)
+		}
+		return nil, err
+	}
+
+	return page, nil
+}
+
 // GetClient 获取 HTTP 客户端
 func (p *Page) GetClient() *HTTPClient {
 	return p.client
@@ -28,11 +69,26 @@ func (p *Page) Navigate(url string) error {
 	return p.client.Navigate(url)
 }
 
+// NavigateWithResponse 导航到 URL 并返回主文档的状态码和响应头
+func (p *Page) NavigateWithResponse(url string) (*NavResponse, error) {
+	return p.client.NavigateWithResponse(url)
+}
+
+// NavigateWithOptions 按选项导航到 URL，可设置 Referer、加载状态和超时
+func (p *Page) NavigateWithOptions(url string, opts NavigateOptions) error {
+	return p.client.NavigateWithOptions(url, opts)
+}
+
 // NavigateWithLoadedState 导航并等待加载完成
 func (p *Page) NavigateWithLoadedState(url string) error {
 	return p.client.NavigateWithLoadedState(url)
 }
 
+// SetContent 直接加载 HTML 内容，waitUntil 为 load、domcontentloaded 或 networkidle
+func (p *Page) SetContent(html string, waitUntil string) error {
+	return p.client.SetContent(html, waitUntil)
+}
+
 // Reload 刷新页面
 func (p *Page) Reload() error {
 	return p.client.Reload()
@@ -55,11 +111,21 @@ func (p *Page) GetURL() (string, error) {
 	return p.client.GetURL()
 }
 
-// GetHTML 获取页面 HTML
+// GetHTML 获取页面 HTML，不保证包含 doctype；需要完整文档时使用 Content
 func (p *Page) GetHTML() (string, error) {
 	return p.client.GetHTML()
 }
 
+// Content 获取完整的页面 HTML，包含 doctype（如有）
+func (p *Page) Content() (string, error) {
+	return p.client.GetContent()
+}
+
+// Frames 获取页面中所有 frame
+func (p *Page) Frames() ([]FrameInfo, error) {
+	return p.client.GetFrames()
+}
+
 // ========== 脚本执行 ==========
 
 // ExecuteScript 执行 JavaScript 并返回结果
@@ -67,6 +133,21 @@ func (p *Page) ExecuteScript(script string) (any, error) {
 	return p.client.ExecuteScript(script)
 }
 
+// ExecuteScriptResult 执行 JavaScript 并返回 ScriptResult，便于类型转换
+func (p *Page) ExecuteScriptResult(script string) (*ScriptResult, error) {
+	return p.client.ExecuteScriptResult(script)
+}
+
+// Evaluate 执行 JavaScript 函数体，args 以 arguments 形式传入
+func (p *Page) Evaluate(script string, args ...any) (any, error) {
+	return p.client.Evaluate(script, args...)
+}
+
+// EvalOnAll 对所有匹配 selector 的元素执行一次 JavaScript 函数，函数接收元素数组作为参数
+func (p *Page) EvalOnAll(selector, script string) ([]any, error) {
+	return p.client.EvalOnAll(selector, script)
+}
+
 // ========== 等待操作 ==========
 
 // WaitForLoadStateLoad 等待页面加载完成
@@ -79,14 +160,56 @@ func (p *Page) WaitForDomContentLoaded() error {
 	return p.client.WaitForDomContentLoaded()
 }
 
+// WaitForNetworkIdle 等待网络空闲，idleTime 为需要保持空闲的时长（默认 500ms）
+func (p *Page) WaitForNetworkIdle(idleTime time.Duration, timeout time.Duration) error {
+	return p.client.WaitForNetworkIdle(idleTime, timeout)
+}
+
 // WaitForSelectorVisible 等待元素可见
 func (p *Page) WaitForSelectorVisible(selector string) error {
-	return p.client.WaitForSelectorVisible(selector)
+	return p.client.waitForSelectorVisible(selector, p.timeout)
+}
+
+// WaitForSelectorHidden 等待元素从 DOM 中移除或不可见，timeout 为 0 时使用页面默认超时
+func (p *Page) WaitForSelectorHidden(selector string, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = p.timeout
+	}
+	return p.client.WaitForSelectorHidden(selector, timeout)
+}
+
+// RandomWait 随机等待 min 到 max 毫秒
+func (p *Page) RandomWait(min, max int) error {
+	return p.client.RandomWait(min, max)
+}
+
+// RandomWaitTier 按命名区间随机等待：short、middle、long
+func (p *Page) RandomWaitTier(tier string) error {
+	return p.client.RandomWaitTier(tier)
+}
+
+// WaitForURL 等待页面 URL 匹配 pattern（完整 URL 或 glob 通配符）
+func (p *Page) WaitForURL(pattern string, timeout time.Duration) error {
+	return p.client.WaitForURL(pattern, timeout)
+}
+
+// WaitForNavigation 执行 action 并等待其触发的导航完成，waitUntil 为 load、domcontentloaded 或 networkidle
+func (p *Page) WaitForNavigation(action func() error, waitUntil string, timeout time.Duration) error {
+	return p.client.WaitForNavigation(action, waitUntil, timeout)
 }
 
-// Wait 等待元素
+// Wait 等待元素，超时由 SetDefaultTimeout 设置，默认 10 秒
 func (p *Page) Wait(selector string) error {
-	return p.client.ElementWait(selector, 10000)
+	timeout := p.timeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	return p.client.ElementWait(selector, int(timeout.Milliseconds()))
+}
+
+// WaitForElementText 等待元素文本等于 expected，timeoutMs 为毫秒，为 0 时使用页面默认超时
+func (p *Page) WaitForElementText(selector, expected string, timeoutMs int) error {
+	return p.Locator(selector).WaitForText(expected, timeoutMs)
 }
 
 // ========== 高级功能 ==========
@@ -101,11 +224,83 @@ func (p *Page) InnerText(selector string) (string, error) {
 	return p.client.InnerText(selector)
 }
 
+// InnerTextAll 获取整个页面的可见文本（document.body.innerText），不含脚本和样式
+func (p *Page) InnerTextAll() (string, error) {
+	result, err := p.ExecuteScript("document.body ? document.body.innerText : ''")
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := result.(string); ok {
+		return text, nil
+	}
+
+	return "", fmt.Errorf("text not found in response")
+}
+
+// VisibleText 获取 selector 元素子树的可见文本（innerText），等同于 InnerText
+func (p *Page) VisibleText(selector string) (string, error) {
+	return p.InnerText(selector)
+}
+
 // TextContent 获取文本内容
 func (p *Page) TextContent(selector string) (string, error) {
 	return p.client.TextContent(selector)
 }
 
+// EmulateNetworkConditions 模拟网络环境
+func (p *Page) EmulateNetworkConditions(offline bool, downloadKbps, uploadKbps, latencyMs int) error {
+	return p.client.EmulateNetworkConditions(offline, downloadKbps, uploadKbps, latencyMs)
+}
+
+// EmulateNetworkPreset 按预设模拟网络环境，如 Slow 3G
+func (p *Page) EmulateNetworkPreset(name string) error {
+	return p.client.EmulateNetworkPreset(name)
+}
+
+// EmulateCPU 模拟 CPU 降速，rate 为降速倍数
+func (p *Page) EmulateCPU(rate float64) error {
+	return p.client.EmulateCPU(rate)
+}
+
+// SetBasicAuth 设置 HTTP Basic 认证凭据，均为空时清除
+func (p *Page) SetBasicAuth(username, password string) error {
+	return p.client.SetBasicAuth(username, password)
+}
+
+// EmulateMedia 模拟 CSS 媒体类型（screen、print）和配色方案（light、dark、no-preference）
+func (p *Page) EmulateMedia(media string, colorScheme string) error {
+	return p.client.EmulateMedia(media, colorScheme)
+}
+
+// GetPageErrors 获取 since 之后页面抛出的未捕获 JavaScript 错误
+func (p *Page) GetPageErrors(since time.Time) ([]PageError, error) {
+	return p.client.GetPageErrors(since)
+}
+
+// AssertNoPageErrors 检查 since 之后页面没有抛出 JavaScript 错误，否则返回列出所有错误的 error
+func (p *Page) AssertNoPageErrors(since time.Time) error {
+	pageErrors, err := p.GetPageErrors(since)
+	if err != nil {
+		return err
+	}
+
+	if len(pageErrors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(pageErrors))
+	for i, pageErr := range pageErrors {
+		messages[i] = pageErr.Message
+	}
+	return fmt.Errorf("%d page errors occurred: %s", len(pageErrors), strings.Join(messages, "; "))
+}
+
+// AutoDismissDialogs 自动处理页面对话框
+func (p *Page) AutoDismissDialogs(accept bool) error {
+	return p.client.AutoDismissDialogs(accept)
+}
+
 // Release 释放页面
 
 // Close 关闭页面
@@ -113,6 +308,83 @@ func (p *Page) Close() error {
 	return p.client.Close()
 }
 
+// ========== 请求拦截 ==========
+
+// SetRequestInterception 设置请求拦截规则
+func (p *Page) SetRequestInterception(rules []InterceptRule) error {
+	return p.client.SetRequestInterception(rules)
+}
+
+// RemoveRequestInterception 清除所有请求拦截规则
+func (p *Page) RemoveRequestInterception() error {
+	return p.client.RemoveRequestInterception()
+}
+
+// Route 对匹配 urlPattern 的请求返回模拟响应
+func (p *Page) Route(urlPattern string, response MockResponse) error {
+	return p.client.Route(urlPattern, response)
+}
+
+// Unroute 移除 urlPattern 对应的路由
+func (p *Page) Unroute(urlPattern string) error {
+	return p.client.Unroute(urlPattern)
+}
+
+// ========== HAR 录制 ==========
+
+// StartHARRecording 开始录制 HAR
+func (p *Page) StartHARRecording() error {
+	return p.client.StartHARRecording()
+}
+
+// StopHARRecording 停止录制并返回 HAR JSON
+func (p *Page) StopHARRecording() ([]byte, error) {
+	return p.client.StopHARRecording()
+}
+
+// ========== 性能追踪 ==========
+
+// StartTracing 开始 Chrome 性能追踪
+func (p *Page) StartTracing(categories []string) error {
+	return p.client.StartTracing(categories)
+}
+
+// StopTracing 停止性能追踪并返回 trace JSON
+func (p *Page) StopTracing() ([]byte, error) {
+	return p.client.StopTracing()
+}
+
+// ========== Cookie ==========
+
+// DeleteCookie 删除指定名称的 cookie
+func (p *Page) DeleteCookie(name, domain string) error {
+	return p.client.DeleteCookie(name, domain)
+}
+
+// ========== 事件 ==========
+
+// StreamEvents 订阅页面事件，返回的 stop 用于取消订阅
+func (p *Page) StreamEvents(events []string, handler func(Event)) (stop func(), err error) {
+	return p.client.StreamEvents(events, handler)
+}
+
+// StreamEventsWithOptions 按缓冲选项订阅页面事件
+func (p *Page) StreamEventsWithOptions(events []string, handler func(Event), opts StreamOptions) (stop func(), err error) {
+	return p.client.StreamEventsWithOptions(events, handler, opts)
+}
+
+// ========== 下载 ==========
+
+// SetDownloadPath 设置下载目录
+func (p *Page) SetDownloadPath(dir string) error {
+	return p.client.SetDownloadPath(dir)
+}
+
+// ExpectDownload 等待由 trigger 触发的下载
+func (p *Page) ExpectDownload(trigger func() error, timeout time.Duration) (*Download, error) {
+	return p.client.ExpectDownload(trigger, timeout)
+}
+
 // ========== 截图 ==========
 
 // Screenshot 截图
@@ -120,6 +392,16 @@ func (p *Page) Screenshot(format string) ([]byte, error) {
 	return p.client.Screenshot(format)
 }
 
+// ScreenshotWithOptions 按选项截图
+func (p *Page) ScreenshotWithOptions(opts ScreenshotOptions) ([]byte, error) {
+	return p.client.ScreenshotWithOptions(opts)
+}
+
+// ScreenshotElements 一次请求截取多个元素，返回按选择器索引的图片数据
+func (p *Page) ScreenshotElements(selectors []string, format string) (map[string][]byte, error) {
+	return p.client.ElementScreenshots(selectors, format)
+}
+
 // ========== 元素操作快捷方式 ==========
 
 // Locator 创建定位器
@@ -128,9 +410,38 @@ func (p *Page) Locator(selector string) *Locator {
 		client:    p.client,
 		selector:  selector,
 		selectors: []string{selector},
+		retries:   defaultLocatorRetries,
+		page:      p,
 	}
 }
 
+// LocatorAny 按候选选择器依次尝试，定位到第一个有匹配的选择器，由服务端在每次查询时解析
+func (p *Page) LocatorAny(selectors ...string) *Locator {
+	if len(selectors) == 0 {
+		return p.Locator("")
+	}
+	return p.Locator(selectors[0]).with("selectors", selectors)
+}
+
+// GetByText 按可见文本定位元素，exact 为 false 时按包含匹配（忽略大小写）
+func (p *Page) GetByText(text string, exact bool) *Locator {
+	return p.Locator(text).with("strategy", "text").with("exact", exact)
+}
+
+// GetByRole 按无障碍角色定位元素，name 非空时同时匹配可访问名称
+func (p *Page) GetByRole(role string, name string) *Locator {
+	locator := p.Locator(role).with("strategy", "role")
+	if name != "" {
+		locator = locator.with("name", name)
+	}
+	return locator
+}
+
+// GetByTestId 按 data-testid 属性定位元素
+func (p *Page) GetByTestId(id string) *Locator {
+	return p.Locator(id).with("strategy", "testid")
+}
+
 // Exists 检查元素是否存在
 func (p *Page) Exists(selector string) (bool, error) {
 	return p.client.ElementExists(selector)
@@ -146,6 +457,31 @@ func (p *Page) Click(selector string) error {
 	return p.client.ElementClick(selector)
 }
 
+// DoubleClick 双击元素
+func (p *Page) DoubleClick(selector string) error {
+	return p.Locator(selector).DoubleClick()
+}
+
+// ClickRight 右键点击元素
+func (p *Page) ClickRight(selector string) error {
+	return p.Locator(selector).ClickRight()
+}
+
+// Focus 聚焦元素并触发 focus 事件
+func (p *Page) Focus(selector string) error {
+	return p.client.ElementFocus(selector)
+}
+
+// Blur 使元素失去焦点并触发 blur 事件
+func (p *Page) Blur(selector string) error {
+	return p.client.ElementBlur(selector)
+}
+
+// DragAndDrop 将 sourceSelector 元素拖放到 targetSelector 元素
+func (p *Page) DragAndDrop(sourceSelector, targetSelector string) error {
+	return p.client.ElementDragTo(sourceSelector, targetSelector)
+}
+
 // SetValue 设置元素值
 func (p *Page) SetValue(selector, value string) error {
 	return p.client.ElementSetValue(selector, value)
@@ -156,7 +492,7 @@ func (p *Page) Attribute(selector, attr string) (string, error) {
 	return p.client.ElementAttribute(selector, attr)
 }
 
-// AllTexts 获取所有匹配元素的文本
+// AllTexts 获取所有匹配元素的文本，等同于 Locator.AllInnerTexts
 func (p *Page) AllTexts(selector string) ([]string, error) {
 	return p.client.ElementAllTexts(selector)
 }
@@ -171,6 +507,96 @@ func (p *Page) Count(selector string) (int, error) {
 	return p.client.ElementCount(selector)
 }
 
+// ========== 键盘 ==========
+
+// PressKey 在当前焦点元素上按下并松开按键
+func (p *Page) PressKey(key string) error {
+	return p.client.PressKey(key)
+}
+
+// activeElementScript 描述当前焦点元素：有 role 时返回 role，否则返回 tag#id.class，焦点在 body 时返回空字符串
+const activeElementScript = `(() => {
+	const el = document.activeElement;
+	if (!el || el === document.body) return "";
+	const role = el.getAttribute("role");
+	if (role) return role;
+	let desc = el.tagName.toLowerCase();
+	if (el.id) desc += "#" + el.id;
+	if (typeof el.className === "string" && el.className.trim()) desc += "." + el.className.trim().split(/\s+/).join(".");
+	return desc;
+})()`
+
+// TabOrder 连续按 Tab 记录焦点顺序，用于无障碍检查
+// 每一步记录焦点元素的 role 或 tag#id.class，焦点回到 body 或达到 maxStops 次时停止
+func (p *Page) TabOrder(maxStops int) ([]string, error) {
+	var order []string
+	for i := 0; i < maxStops; i++ {
+		if err := p.PressKey("Tab"); err != nil {
+			return order, err
+		}
+
+		result, err := p.ExecuteScript(activeElementScript)
+		if err != nil {
+			return order, err
+		}
+
+		desc, _ := result.(string)
+		if desc == "" {
+			break
+		}
+		order = append(order, desc)
+	}
+	return order, nil
+}
+
+// ========== 滚动 ==========
+
+// scrollToBottomScript 滚动到页面底部并返回当前 scrollHeight
+const scrollToBottomScript = `(() => {
+	const height = document.documentElement.scrollHeight;
+	window.scrollTo(0, height);
+	return height;
+})()`
+
+// ScrollPosition 获取页面滚动位置
+func (p *Page) ScrollPosition() (x, y int, err error) {
+	return p.client.GetScrollPosition()
+}
+
+// ScrollTo 滚动页面到绝对位置 (x, y)
+func (p *Page) ScrollTo(x, y int) error {
+	return p.client.ScrollTo(x, y)
+}
+
+// ScrollBy 按像素偏移 (dx, dy) 滚动页面
+func (p *Page) ScrollBy(dx, dy int) error {
+	return p.client.ScrollBy(dx, dy)
+}
+
+// ScrollToBottom 反复滚动到页面底部以加载懒加载内容
+// 每次滚动后等待 delay，当 scrollHeight 不再变化或达到 maxScrolls 次时停止
+func (p *Page) ScrollToBottom(maxScrolls int, delay time.Duration) error {
+	lastHeight := -1.0
+	for i := 0; i < maxScrolls; i++ {
+		result, err := p.ExecuteScript(scrollToBottomScript)
+		if err != nil {
+			return err
+		}
+
+		height, ok := result.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected scrollHeight result: %v", result)
+		}
+		if height == lastHeight {
+			return nil
+		}
+		lastHeight = height
+
+		time.Sleep(delay)
+	}
+	return nil
+}
+
 // ========== 链式操作 ==========
 
 // NavigateThen 导航后执行操作
@@ -0,0 +1,213 @@
+package cdpsdk
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScrollToBottom(t *testing.T) {
+	heights := []int{1000, 2000, 2500, 2500, 3000}
+	var step int
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		height := heights[step]
+		step++
+		return map[string]any{"result": height}, nil
+	})
+
+	if err := page.ScrollToBottom(10, time.Millisecond); err != nil {
+		t.Fatalf("ScrollToBottom: %v", err)
+	}
+	if n := len(fb.Calls()); n != 4 {
+		t.Errorf("scrolled %d times, want 4 (stop once height is stable)", n)
+	}
+
+	step = 0
+	if err := page.ScrollToBottom(2, time.Millisecond); err != nil {
+		t.Fatalf("ScrollToBottom: %v", err)
+	}
+	if n := len(fb.Calls()); n != 6 {
+		t.Errorf("scrolled %d times in total, want 6 (stop at maxScrolls)", n)
+	}
+}
+
+func TestAssertNoPageErrors(t *testing.T) {
+	var pageErrors []any
+	page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"errors": pageErrors}, nil
+	})
+
+	pageErrors = []any{}
+	if err := page.AssertNoPageErrors(time.Now()); err != nil {
+		t.Errorf("AssertNoPageErrors: %v", err)
+	}
+
+	pageErrors = []any{
+		map[string]any{"message": "x is undefined"},
+		map[string]any{"message": "fetch failed"},
+	}
+	err := page.AssertNoPageErrors(time.Now())
+	if err == nil {
+		t.Fatal("AssertNoPageErrors: expected error")
+	}
+	for _, want := range []string{"2 page errors", "x is undefined", "fetch failed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestPageFocusAndBlur(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.Focus("#email"); err != nil {
+		t.Fatalf("Focus: %v", err)
+	}
+	if err := page.Blur("#email"); err != nil {
+		t.Fatalf("Blur: %v", err)
+	}
+	assertEndpoints(t, fb, "/api/element/focus", "/api/element/blur")
+}
+
+func TestTabOrder(t *testing.T) {
+	focus := []string{"input#email", "input#password", "button", ""}
+	var step int
+	page, fb := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/page/execute" {
+			desc := focus[step]
+			step++
+			return map[string]any{"result": desc}, nil
+		}
+		return nil, nil
+	})
+
+	order, err := page.TabOrder(10)
+	if err != nil {
+		t.Fatalf("TabOrder: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"input#email", "input#password", "button"}) {
+		t.Errorf("order = %v", order)
+	}
+	assertBody(t, fb.Calls()[0], "key", "Tab")
+
+	step = 0
+	order, err = page.TabOrder(2)
+	if err != nil {
+		t.Fatalf("TabOrder: %v", err)
+	}
+	if len(order) != 2 {
+		t.Errorf("order = %v, want 2 stops", order)
+	}
+}
+
+// newPageBackend 模拟服务端：new-page 返回新页面的会话 ID，navigateErr 非 nil 时导航失败
+func newPageBackend(navigateErr error) func(call fakeCall) (map[string]any, error) {
+	return func(call fakeCall) (map[string]any, error) {
+		switch call.Endpoint {
+		case "/api/context/new-page":
+			return map[string]any{"sessionId": "page-2"}, nil
+		case "/api/page/navigate":
+			return nil, navigateErr
+		}
+		return nil, nil
+	}
+}
+
+func TestNewPageWithURL(t *testing.T) {
+	hc, fb := newFakeClient(t, newPageBackend(nil))
+
+	page, err := hc.NewPageWithURL("https://example.com", true)
+	if err != nil {
+		t.Fatalf("NewPageWithURL: %v", err)
+	}
+	if page.GetClient() == hc || page.GetClient().sessionId != "page-2" {
+		t.Error("page is not bound to the new page session")
+	}
+	assertEndpoints(t, fb, "/api/context/new-page", "/api/page/navigate-with-loaded-state")
+	calls := fb.Calls()
+	assertBody(t, calls[0], "sessionId", "test-session")
+	assertNoBodyKey(t, calls[0], "contextId")
+	assertBody(t, calls[1], "sessionId", "page-2")
+}
+
+func TestNewPageWithURLFailureClosesPage(t *testing.T) {
+	hc, fb := newFakeClient(t, newPageBackend(errors.New("net::ERR_NAME_NOT_RESOLVED")))
+
+	if _, err := hc.NewPageWithURL("https://missing.invalid", false); err == nil {
+		t.Fatal("NewPageWithURL: expected error")
+	}
+	// 只关闭新建的页面，不影响当前会话的页面
+	assertEndpoints(t, fb, "/api/context/new-page", "/api/page/navigate", "/api/page/close")
+	assertBody(t, fb.lastCall(t), "sessionId", "page-2")
+}
+
+func TestNewPageWithURLInvalidURL(t *testing.T) {
+	hc, fb := newFakeClient(t, newPageBackend(nil))
+
+	if _, err := hc.NewPageWithURL("www.baidu.com", false); err == nil {
+		t.Fatal("NewPageWithURL: expected error")
+	}
+	if n := len(fb.Calls()); n != 0 {
+		t.Errorf("sent %d requests for an invalid URL", n)
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.Wait("#app"); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", defaultWaitTimeout.Milliseconds())
+
+	page.SetDefaultTimeout(3 * time.Second)
+	if err := page.Wait("#app"); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", 3000)
+
+	page.SetDefaultTimeout(0)
+	if err := page.Wait("#app"); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", defaultWaitTimeout.Milliseconds())
+}
+
+func TestServerWaitsUseServerDefaultUnlessSet(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+
+	if err := page.WaitForSelectorVisible("#app"); err != nil {
+		t.Fatalf("WaitForSelectorVisible: %v", err)
+	}
+	if err := page.WaitForSelectorHidden("#spinner", 0); err != nil {
+		t.Fatalf("WaitForSelectorHidden: %v", err)
+	}
+	if err := page.Locator("#app").WaitFor("visible", 0); err != nil {
+		t.Fatalf("WaitFor: %v", err)
+	}
+	for _, call := range fb.Calls() {
+		assertNoBodyKey(t, call, "timeout")
+	}
+
+	page.SetDefaultTimeout(2 * time.Second)
+	if err := page.WaitForSelectorVisible("#app"); err != nil {
+		t.Fatalf("WaitForSelectorVisible: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", 2000)
+	if err := page.WaitForSelectorHidden("#spinner", 0); err != nil {
+		t.Fatalf("WaitForSelectorHidden: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", 2000)
+	if err := page.Locator("#app").WaitFor("visible", 0); err != nil {
+		t.Fatalf("WaitFor: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", 2000)
+
+	// 显式传入的超时优先于页面默认超时
+	if err := page.WaitForSelectorHidden("#spinner", 500*time.Millisecond); err != nil {
+		t.Fatalf("WaitForSelectorHidden: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "timeout", 500)
+}
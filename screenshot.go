@@ -0,0 +1,136 @@
+package cdpsdk
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// screenshotFormats 支持的截图格式
+var screenshotFormats = []string{"png", "jpeg", "webp"}
+
+// validateScreenshotFormat 校验截图格式
+func validateScreenshotFormat(format string) error {
+	if !slices.Contains(screenshotFormats, format) {
+		return fmt.Errorf("unsupported screenshot format %q, expected one of %s", format, strings.Join(screenshotFormats, ", "))
+	}
+	return nil
+}
+
+// SetDefaultScreenshotFormat 设置截图未指定格式时使用的默认格式，初始为 png
+func (hc *HTTPClient) SetDefaultScreenshotFormat(format string) error {
+	if err := validateScreenshotFormat(format); err != nil {
+		return err
+	}
+	hc.screenshotFormat = format
+	return nil
+}
+
+// screenshotFormatOrDefault 校验截图格式，format 为空时返回默认格式
+func (hc *HTTPClient) screenshotFormatOrDefault(format string) (string, error) {
+	if format == "" {
+		return hc.screenshotFormat, nil
+	}
+	if err := validateScreenshotFormat(format); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// screenshotFormatFromPath 根据文件扩展名推断截图格式
+func screenshotFormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		return "png", nil
+	case ".jpg", ".jpeg":
+		return "jpeg", nil
+	case ".webp":
+		return "webp", nil
+	default:
+		return "", fmt.Errorf("cannot infer screenshot format from %q", path)
+	}
+}
+
+// screenshotToFile 截图并写入 path，format 为空时根据扩展名推断
+func screenshotToFile(path, format string, capture func(format string) ([]byte, error)) error {
+	if format == "" {
+		var err error
+		format, err = screenshotFormatFromPath(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := capture(format)
+	if err != nil {
+		return fmt.Errorf("failed to capture screenshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+
+	return nil
+}
+
+// ScreenshotToFile 页面截图并保存到 path，format 为空时根据扩展名推断
+func (p *Page) ScreenshotToFile(path, format string) error {
+	return screenshotToFile(path, format, p.Screenshot)
+}
+
+// ScreenshotToFile 元素截图并保存到 path，format 为空时根据扩展名推断
+func (l *Locator) ScreenshotToFile(path, format string) error {
+	return screenshotToFile(path, format, l.Screenshot)
+}
+
+// CompareScreenshots 逐像素比较两张截图（png 或 jpeg），返回不同像素所占比例 diff，
+// diff 不超过 threshold（0 到 1）时 match 为 true；两张图片尺寸不同时返回错误
+func CompareScreenshots(a, b []byte, threshold float64) (match bool, diff float64, err error) {
+	if threshold < 0 || threshold > 1 {
+		return false, 0, fmt.Errorf("threshold must be between 0 and 1, got %v", threshold)
+	}
+
+	imgA, _, err := image.Decode(bytes.NewReader(a))
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to decode first screenshot: %w", err)
+	}
+	imgB, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to decode second screenshot: %w", err)
+	}
+
+	boundsA, boundsB := imgA.Bounds(), imgB.Bounds()
+	if boundsA.Dx() != boundsB.Dx() || boundsA.Dy() != boundsB.Dy() {
+		return false, 0, fmt.Errorf("screenshot dimensions differ: %dx%d vs %dx%d",
+			boundsA.Dx(), boundsA.Dy(), boundsB.Dx(), boundsB.Dy())
+	}
+
+	total := boundsA.Dx() * boundsA.Dy()
+	if total == 0 {
+		return true, 0, nil
+	}
+
+	different := 0
+	for y := 0; y < boundsA.Dy(); y++ {
+		for x := 0; x < boundsA.Dx(); x++ {
+			r1, g1, b1, a1 := imgA.At(boundsA.Min.X+x, boundsA.Min.Y+y).RGBA()
+			r2, g2, b2, a2 := imgB.At(boundsB.Min.X+x, boundsB.Min.Y+y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				different++
+			}
+		}
+	}
+
+	diff = float64(different) / float64(total)
+	return diff <= threshold, diff, nil
+}
@@ -0,0 +1,114 @@
+package cdpsdk
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScreenshotToFile(t *testing.T) {
+	page, fb := newFakePage(t, nil)
+	fb.binary = func(call fakeCall) ([]byte, error) {
+		return []byte("image-" + call.Body["format"].(string)), nil
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shots", "home.jpg")
+	if err := page.ScreenshotToFile(path, ""); err != nil {
+		t.Fatalf("ScreenshotToFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "image-jpeg" {
+		t.Errorf("file contents = %q", data)
+	}
+
+	if err := page.Locator("#chart").ScreenshotToFile(filepath.Join(dir, "chart.bin"), "png"); err != nil {
+		t.Fatalf("ScreenshotToFile: %v", err)
+	}
+	if err := page.ScreenshotToFile(filepath.Join(dir, "chart.bin"), ""); err == nil {
+		t.Error("ScreenshotToFile: expected error for unknown extension")
+	}
+}
+
+func TestScreenshotFormat(t *testing.T) {
+	hc, fb := newFakeClient(t, nil)
+
+	if _, err := hc.Screenshot(""); err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "format", "png")
+
+	if err := hc.SetDefaultScreenshotFormat("webp"); err != nil {
+		t.Fatalf("SetDefaultScreenshotFormat: %v", err)
+	}
+	if _, err := hc.ElementScreenshot("#chart", ""); err != nil {
+		t.Fatalf("ElementScreenshot: %v", err)
+	}
+	assertBody(t, fb.lastCall(t), "format", "webp")
+
+	sent := len(fb.Calls())
+	if _, err := hc.Screenshot("jpg"); err == nil {
+		t.Error("Screenshot: expected error for unsupported format")
+	}
+	if err := hc.SetDefaultScreenshotFormat("gif"); err == nil {
+		t.Error("SetDefaultScreenshotFormat: expected error for unsupported format")
+	}
+	if n := len(fb.Calls()); n != sent {
+		t.Errorf("unsupported formats sent %d requests", n-sent)
+	}
+}
+
+// encodePNG 生成 w×h 的纯色 PNG，diff 个像素改为红色
+func encodePNG(t *testing.T, w, h, diff int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < w*h; i++ {
+		c := color.RGBA{255, 255, 255, 255}
+		if i < diff {
+			c = color.RGBA{255, 0, 0, 255}
+		}
+		img.Set(i%w, i/w, c)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCompareScreenshots(t *testing.T) {
+	base := encodePNG(t, 10, 10, 0)
+
+	match, diff, err := CompareScreenshots(base, encodePNG(t, 10, 10, 0), 0)
+	if err != nil || !match || diff != 0 {
+		t.Errorf("identical: match=%v diff=%v err=%v", match, diff, err)
+	}
+
+	match, diff, err = CompareScreenshots(base, encodePNG(t, 10, 10, 5), 0.01)
+	if err != nil || match || diff != 0.05 {
+		t.Errorf("5%% different, threshold 1%%: match=%v diff=%v err=%v", match, diff, err)
+	}
+
+	match, _, err = CompareScreenshots(base, encodePNG(t, 10, 10, 5), 0.1)
+	if err != nil || !match {
+		t.Errorf("5%% different, threshold 10%%: match=%v err=%v", match, err)
+	}
+
+	if _, _, err := CompareScreenshots(base, encodePNG(t, 10, 20, 0), 0); err == nil {
+		t.Error("CompareScreenshots: expected error for different dimensions")
+	}
+	if _, _, err := CompareScreenshots(base, []byte("not an image"), 0); err == nil {
+		t.Error("CompareScreenshots: expected error for invalid image")
+	}
+	if _, _, err := CompareScreenshots(base, base, 2); err == nil {
+		t.Error("CompareScreenshots: expected error for invalid threshold")
+	}
+}
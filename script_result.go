@@ -0,0 +1,85 @@
+package cdpsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// ScriptResult 脚本执行结果，提供类型转换方法
+type ScriptResult struct {
+	value any
+}
+
+// NewScriptResult 包装脚本执行结果
+func NewScriptResult(value any) *ScriptResult {
+	return &ScriptResult{value: value}
+}
+
+// Value 获取原始值
+func (r *ScriptResult) Value() any {
+	return r.value
+}
+
+// IsNull 判断结果是否为 null 或 undefined
+func (r *ScriptResult) IsNull() bool {
+	return r.value == nil
+}
+
+// String 获取字符串结果
+func (r *ScriptResult) String() (string, error) {
+	if s, ok := r.value.(string); ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("script result is %T, not string", r.value)
+}
+
+// Float 获取数值结果
+func (r *ScriptResult) Float() (float64, error) {
+	if f, ok := r.value.(float64); ok {
+		return f, nil
+	}
+	return 0, fmt.Errorf("script result is %T, not number", r.value)
+}
+
+// Int 获取整数结果，数值带小数时返回错误
+func (r *ScriptResult) Int() (int, error) {
+	f, err := r.Float()
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("script result %v is not an integer", f)
+	}
+	return int(f), nil
+}
+
+// Bool 获取布尔结果
+func (r *ScriptResult) Bool() (bool, error) {
+	if b, ok := r.value.(bool); ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("script result is %T, not bool", r.value)
+}
+
+// Unmarshal 将结果解码到 v，用于对象和数组
+func (r *ScriptResult) Unmarshal(v any) error {
+	data, err := json.Marshal(r.value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal script result: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal script result: %w", err)
+	}
+	return nil
+}
+
+// ExecuteScriptResult 执行 JavaScript 并返回 ScriptResult
+func (hc *HTTPClient) ExecuteScriptResult(script string) (*ScriptResult, error) {
+	value, err := hc.ExecuteScript(script)
+	if err != nil {
+		return nil, err
+	}
+	return NewScriptResult(value), nil
+}
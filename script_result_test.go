@@ -0,0 +1,54 @@
+package cdpsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptResult(t *testing.T) {
+	hc, _ := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"result": map[string]any{
+			"title": "Go Engineer",
+			"tags":  []any{"go", "remote"},
+			"count": 3,
+		}}, nil
+	})
+
+	result, err := hc.ExecuteScriptResult("({title: document.title})")
+	if err != nil {
+		t.Fatalf("ExecuteScriptResult: %v", err)
+	}
+
+	var job struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+		Count int      `json:"count"`
+	}
+	if err := result.Unmarshal(&job); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if job.Title != "Go Engineer" || !reflect.DeepEqual(job.Tags, []string{"go", "remote"}) || job.Count != 3 {
+		t.Errorf("job = %+v", job)
+	}
+}
+
+func TestScriptResultConversions(t *testing.T) {
+	if s, err := NewScriptResult("text").String(); err != nil || s != "text" {
+		t.Errorf("String = %q, %v", s, err)
+	}
+	if n, err := NewScriptResult(42.0).Int(); err != nil || n != 42 {
+		t.Errorf("Int = %d, %v", n, err)
+	}
+	if _, err := NewScriptResult(1.5).Int(); err == nil {
+		t.Error("Int: expected error for non-integer")
+	}
+	if b, err := NewScriptResult(true).Bool(); err != nil || !b {
+		t.Errorf("Bool = %v, %v", b, err)
+	}
+	if _, err := NewScriptResult("1").Float(); err == nil {
+		t.Error("Float: expected error for string")
+	}
+	if !NewScriptResult(nil).IsNull() {
+		t.Error("IsNull = false for nil")
+	}
+}
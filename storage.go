@@ -0,0 +1,77 @@
+package cdpsdk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Cookie 浏览器 cookie
+type Cookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires"` // Unix 时间戳（秒），-1 表示会话 cookie
+	HTTPOnly bool    `json:"httpOnly"`
+	Secure   bool    `json:"secure"`
+	SameSite string  `json:"sameSite,omitempty"`
+}
+
+// StorageState 会话状态，包含 cookie、localStorage 和 sessionStorage
+type StorageState struct {
+	Cookies        []Cookie          `json:"cookies"`
+	LocalStorage   map[string]string `json:"localStorage"`
+	SessionStorage map[string]string `json:"sessionStorage"`
+}
+
+// Marshal 序列化为 JSON，便于保存到文件
+func (s *StorageState) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// ParseStorageState 从 JSON 解析会话状态
+func ParseStorageState(data []byte) (*StorageState, error) {
+	var state StorageState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage state: %w", err)
+	}
+	return &state, nil
+}
+
+// SaveStorageState 获取当前页面的会话状态
+func (hc *HTTPClient) SaveStorageState() (*StorageState, error) {
+	endpoint := fmt.Sprintf("/api/page/storage-state?sessionId=%s", hc.sessionId)
+
+	resp, err := hc.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal storage state: %w", err)
+	}
+
+	return ParseStorageState(data)
+}
+
+// RestoreStorageState 恢复会话状态，localStorage 和 sessionStorage 写入当前页面所在的源
+func (hc *HTTPClient) RestoreStorageState(state *StorageState) error {
+	body := map[string]any{
+		"sessionId": hc.sessionId,
+		"state":     state,
+	}
+
+	_, err := hc.doRequest("POST", "/api/page/set-storage-state", body)
+	return err
+}
+
+// SaveStorageState 获取当前页面的会话状态
+func (p *Page) SaveStorageState() (*StorageState, error) {
+	return p.client.SaveStorageState()
+}
+
+// RestoreStorageState 恢复会话状态
+func (p *Page) RestoreStorageState(state *StorageState) error {
+	return p.client.RestoreStorageState(state)
+}
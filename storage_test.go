@@ -0,0 +1,62 @@
+package cdpsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStorageStateRoundTrip(t *testing.T) {
+	var saved map[string]any
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		if call.Endpoint == "/api/page/set-storage-state" {
+			saved = call.Body["state"].(map[string]any)
+			return nil, nil
+		}
+		return map[string]any{
+			"cookies": []any{map[string]any{
+				"name": "sid", "value": "abc", "domain": ".example.com", "path": "/",
+				"expires": -1, "httpOnly": true, "secure": true, "sameSite": "Lax",
+			}},
+			"localStorage":   map[string]any{"theme": "dark"},
+			"sessionStorage": map[string]any{"step": "2"},
+		}, nil
+	})
+	page := NewPage(hc)
+
+	state, err := page.SaveStorageState()
+	if err != nil {
+		t.Fatalf("SaveStorageState: %v", err)
+	}
+	want := &StorageState{
+		Cookies: []Cookie{{
+			Name: "sid", Value: "abc", Domain: ".example.com", Path: "/",
+			Expires: -1, HTTPOnly: true, Secure: true, SameSite: "Lax",
+		}},
+		LocalStorage:   map[string]string{"theme": "dark"},
+		SessionStorage: map[string]string{"step": "2"},
+	}
+	if !reflect.DeepEqual(state, want) {
+		t.Fatalf("state = %+v, want %+v", state, want)
+	}
+
+	data, err := state.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	restored, err := ParseStorageState(data)
+	if err != nil {
+		t.Fatalf("ParseStorageState: %v", err)
+	}
+	if err := page.RestoreStorageState(restored); err != nil {
+		t.Fatalf("RestoreStorageState: %v", err)
+	}
+
+	assertEndpoints(t, fb, "/api/page/storage-state", "/api/page/set-storage-state")
+	if !reflect.DeepEqual(saved, jsonValue(want)) {
+		t.Errorf("restored state = %v, want %v", saved, jsonValue(want))
+	}
+
+	if _, err := ParseStorageState([]byte("{")); err == nil {
+		t.Error("ParseStorageState: expected error for invalid JSON")
+	}
+}
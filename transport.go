@@ -0,0 +1,200 @@
+package cdpsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// TransportRequest 传输层请求
+type TransportRequest struct {
+	Method   string
+	URL      string
+	Endpoint string // 相对 baseURL 的路径，如 /api/page/navigate
+	Header   http.Header
+	Body     []byte        // JSON 请求体，无请求体时为 nil
+	Timeout  time.Duration // 单次请求超时，0 表示使用客户端默认超时
+}
+
+// Logger 请求日志回调，每次请求结束后调用
+// reqBody、respBody 为原始数据，由调用方自行脱敏和格式化；二进制响应只传入前 maxLoggedBinaryBytes 字节；
+// 请求发送失败时 status 为 0；数据流请求（Stream）在连接建立后调用，respBody 为 nil
+type Logger func(method, endpoint string, reqBody, respBody []byte, status int, duration time.Duration)
+
+// maxLoggedBinaryBytes 二进制响应传给 Logger 的最大字节数
+const maxLoggedBinaryBytes = 512
+
+// Transport 请求传输层，默认基于 net/http，可通过 WithTransport 替换以便在测试中模拟服务端
+type Transport interface {
+	// Do 执行请求并解析 JSON 响应，服务端返回 success=false 时返回错误
+	Do(req *TransportRequest) (*HTTPResponse, error)
+	// DoBinary 执行请求并返回原始响应数据
+	DoBinary(req *TransportRequest) ([]byte, error)
+	// Stream 执行请求并返回响应数据流，用于 SSE 等长连接；ctx 取消后数据流的读取返回错误，调用方负责 Close
+	Stream(ctx context.Context, req *TransportRequest) (io.ReadCloser, error)
+}
+
+// httpTransport 基于 net/http 的默认传输层
+type httpTransport struct {
+	client  *http.Client
+	retries int    // 网络错误时的重试次数
+	logger  Logger // 请求日志回调，可为 nil
+}
+
+// log 调用请求日志回调
+func (t *httpTransport) log(r *TransportRequest, respBody []byte, status int, start time.Time) {
+	if t.logger != nil {
+		t.logger(r.Method, r.Endpoint, r.Body, respBody, status, time.Since(start))
+	}
+}
+
+// isIdempotentMethod 判断请求方法是否可安全地重复发送
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// send 发送请求并读取响应，网络错误时按 retries 重试
+// 非幂等请求（如 POST 点击、导航）只在请求尚未完整发出时重试，避免服务端重复执行
+func (t *httpTransport) send(r *TransportRequest) (int, http.Header, []byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= max(t.retries, 0); attempt++ {
+		var reqBody io.Reader
+		if r.Body != nil {
+			reqBody = bytes.NewReader(r.Body)
+		}
+
+		var written atomic.Bool
+		ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+			WroteRequest: func(info httptrace.WroteRequestInfo) {
+				if info.Err == nil {
+					written.Store(true)
+				}
+			},
+		})
+		cancel := func() {}
+		if r.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, reqBody)
+		if err != nil {
+			cancel()
+			return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header = r.Header.Clone()
+
+		resp, err := t.client.Do(req)
+		if err != nil {
+			cancel()
+			lastErr = fmt.Errorf("failed to send request: %w", err)
+			if written.Load() && !isIdempotentMethod(r.Method) {
+				break
+			}
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		cancel()
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		return resp.StatusCode, resp.Header, respBody, nil
+	}
+
+	return 0, nil, nil, lastErr
+}
+
+// Do 执行请求并解析 JSON 响应
+func (t *httpTransport) Do(req *TransportRequest) (*HTTPResponse, error) {
+	start := time.Now()
+	status, header, respBody, err := t.send(req)
+	t.log(req, respBody, status, start)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d: %s", status, string(respBody))
+	}
+
+	// baseURL 指向错误的服务时常返回 HTML 页面
+	if contentType := header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "json") {
+		return nil, fmt.Errorf("unexpected non-JSON response from server (content-type %q), check baseURL", contentType)
+	}
+
+	var httpResp HTTPResponse
+	if err := json.Unmarshal(respBody, &httpResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !httpResp.Success {
+		return nil, fmt.Errorf("server error: %s", httpResp.Error)
+	}
+
+	return &httpResp, nil
+}
+
+// DoBinary 执行请求并返回原始响应数据
+func (t *httpTransport) DoBinary(req *TransportRequest) ([]byte, error) {
+	start := time.Now()
+	status, _, respBody, err := t.send(req)
+	t.log(req, respBody[:min(len(respBody), maxLoggedBinaryBytes)], status, start)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", status)
+	}
+
+	return respBody, nil
+}
+
+// Stream 执行请求并返回响应数据流，不重试，也不使用客户端的请求超时
+func (t *httpTransport) Stream(ctx context.Context, r *TransportRequest) (io.ReadCloser, error) {
+	var reqBody io.Reader
+	if r.Body != nil {
+		reqBody = bytes.NewReader(r.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header = r.Header.Clone()
+
+	// 数据流是长连接，不能使用客户端的请求超时
+	streamClient := *t.client
+	streamClient.Timeout = 0
+
+	start := time.Now()
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		t.log(r, nil, 0, start)
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.log(r, respBody, resp.StatusCode, start)
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	t.log(r, nil, resp.StatusCode, start)
+	return resp.Body, nil
+}
@@ -0,0 +1,440 @@
+package cdpsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOptionsCompose(t *testing.T) {
+	hc, fb := newFakeClient(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"title": "ok"}, nil
+	},
+		WithSessionID("s1"),
+		WithTimeout(3*time.Second),
+		WithRetries(2),
+		WithDefaultHeaders(map[string]string{"x-trace-id": "abc"}),
+	)
+
+	if hc.sessionId != "s1" {
+		t.Errorf("sessionId = %q, want s1", hc.sessionId)
+	}
+	if hc.httpClient.Timeout != 3*time.Second {
+		t.Errorf("Timeout = %s, want 3s", hc.httpClient.Timeout)
+	}
+	if hc.retries != 2 {
+		t.Errorf("retries = %d, want 2", hc.retries)
+	}
+
+	if _, err := hc.GetTitle(); err != nil {
+		t.Fatalf("GetTitle: %v", err)
+	}
+	call := fb.lastCall(t)
+	if got := call.Header.Get("X-Trace-Id"); got != "abc" {
+		t.Errorf("X-Trace-Id = %q, want abc", got)
+	}
+	if got := call.Query.Get("sessionId"); got != "s1" {
+		t.Errorf("sessionId query = %q, want s1", got)
+	}
+}
+
+// dropConnection 读取请求后不返回响应直接断开连接
+func dropConnection(w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		panic(err)
+	}
+	conn.Close()
+}
+
+// fakeTransport 记录所有请求，并按 handler 返回响应的传输层，不经过网络
+type fakeTransport struct {
+	mu    sync.Mutex
+	calls []fakeCall
+
+	handler func(call fakeCall) (map[string]any, error)
+	binary  func(call fakeCall) ([]byte, error)
+	stream  func(ctx context.Context, call fakeCall) (io.ReadCloser, error)
+}
+
+func (f *fakeTransport) record(req *TransportRequest) fakeCall {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		panic(err)
+	}
+	call := fakeCall{
+		Method:   req.Method,
+		Endpoint: u.Path,
+		Query:    u.Query(),
+		Header:   req.Header.Clone(),
+	}
+	if req.Body != nil {
+		if err := json.Unmarshal(req.Body, &call.Body); err != nil {
+			panic(err)
+		}
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	f.mu.Unlock()
+	return call
+}
+
+func (f *fakeTransport) Do(req *TransportRequest) (*HTTPResponse, error) {
+	call := f.record(req)
+
+	data := map[string]any{}
+	if f.handler != nil {
+		resp, err := f.handler(call)
+		if err != nil {
+			return nil, err
+		}
+		// 与真实服务端一致，数字解码为 float64
+		if resp != nil {
+			data = jsonValue(resp).(map[string]any)
+		}
+	}
+
+	return &HTTPResponse{Success: true, Data: data}, nil
+}
+
+func (f *fakeTransport) DoBinary(req *TransportRequest) ([]byte, error) {
+	call := f.record(req)
+	if f.binary == nil {
+		return nil, nil
+	}
+	return f.binary(call)
+}
+
+// Calls 返回已记录的请求
+func (f *fakeTransport) Calls() []fakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeCall(nil), f.calls...)
+}
+
+// lastCall 返回最后一次请求，没有请求时测试失败
+func (f *fakeTransport) lastCall(t *testing.T) fakeCall {
+	t.Helper()
+	calls := f.Calls()
+	if len(calls) == 0 {
+		t.Fatal("no request was sent")
+	}
+	return calls[len(calls)-1]
+}
+
+// newFakeTransportClient 创建使用假传输层的客户端
+func newFakeTransportClient(handler func(call fakeCall) (map[string]any, error)) (*HTTPClient, *fakeTransport) {
+	ft := &fakeTransport{handler: handler}
+	hc := NewHTTPClient("http://localhost:3000", WithSessionID("test-session"), WithTransport(ft))
+	return hc, ft
+}
+
+func TestFakeTransportRecordsCalls(t *testing.T) {
+	hc, ft := newFakeTransportClient(nil)
+
+	if err := hc.Navigate("https://example.com"); err != nil {
+		t.Fatalf("Navigate: %v", err)
+	}
+	if _, err := hc.Screenshot("png"); err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+
+	calls := ft.Calls()
+	if len(calls) != 2 || calls[0].Endpoint != "/api/page/navigate" || calls[1].Endpoint != "/api/page/screenshot" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	assertBody(t, calls[0], "sessionId", "test-session")
+	assertBody(t, calls[0], "url", "https://example.com")
+	if calls[0].Method != "POST" {
+		t.Errorf("method = %s, want POST", calls[0].Method)
+	}
+	if got := calls[0].Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+}
+
+func TestBaseURLNormalization(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		writeJSON(w, map[string]any{"title": "ok"})
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL + "/")
+	if _, err := hc.GetTitle(); err != nil {
+		t.Fatalf("GetTitle: %v", err)
+	}
+	if !reflect.DeepEqual(paths, []string{"/api/page/title"}) {
+		t.Errorf("paths = %v, want [/api/page/title]", paths)
+	}
+
+	bad := NewHTTPClient("localhost:3000")
+	if bad.Err() == nil {
+		t.Fatal("Err: expected error for baseURL without scheme")
+	}
+	if _, err := bad.GetTitle(); !errors.Is(err, bad.Err()) {
+		t.Errorf("GetTitle error = %v, want %v", err, bad.Err())
+	}
+}
+
+func TestBearerTokenSentOnNavigate(t *testing.T) {
+	var auth, custom string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		custom = r.Header.Get("X-Api-Key")
+		writeJSON(w, nil)
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL)
+	hc.SetBearerToken("secret")
+	hc.SetDefaultHeader("x-api-key", "key")
+
+	if err := hc.Navigate("https://example.com"); err != nil {
+		t.Fatalf("Navigate: %v", err)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", auth, "Bearer secret")
+	}
+	if custom != "key" {
+		t.Errorf("X-Api-Key = %q, want key", custom)
+	}
+}
+
+func TestLoggerCalledOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"title": "Example"})
+	}))
+	defer server.Close()
+
+	type entry struct {
+		method, endpoint string
+		status           int
+		respBody         string
+	}
+	var entries []entry
+
+	hc := NewHTTPClient(server.URL, WithSessionID("s1"))
+	hc.SetLogger(func(method, endpoint string, reqBody, respBody []byte, status int, duration time.Duration) {
+		entries = append(entries, entry{method, endpoint, status, string(respBody)})
+	})
+
+	if _, err := hc.GetTitle(); err != nil {
+		t.Fatalf("GetTitle: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("logger called %d times, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.method != "GET" || e.endpoint != "/api/page/title?sessionId=s1" || e.status != 200 {
+		t.Errorf("log entry = %+v", e)
+	}
+	if !strings.Contains(e.respBody, "Example") {
+		t.Errorf("logged response body = %q, want it to contain the title", e.respBody)
+	}
+}
+
+func TestHTMLResponseRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, "<!DOCTYPE html><html><body>Not the API</body></html>")
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL)
+	_, err := hc.GetTitle()
+	if err == nil || !strings.Contains(err.Error(), "non-JSON") {
+		t.Fatalf("GetTitle error = %v, want non-JSON response error", err)
+	}
+}
+
+func TestPingAndVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/health":
+			w.WriteHeader(http.StatusOK)
+		case "/api/browser/version":
+			writeJSON(w, map[string]any{
+				"product":         "Chrome/120.0.6099.109",
+				"protocolVersion": "1.3",
+				"userAgent":       "Mozilla/5.0",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL)
+	if err := hc.Ping(); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+
+	version, err := hc.Version()
+	if err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+	want := BrowserVersion{Product: "Chrome/120.0.6099.109", ProtocolVersion: "1.3", UserAgent: "Mozilla/5.0"}
+	if version != want {
+		t.Errorf("Version = %+v, want %+v", version, want)
+	}
+
+	server.Close()
+	if err := hc.Ping(); err == nil {
+		t.Error("Ping: expected error after server shutdown")
+	}
+}
+
+func TestSetTransportConfigReusesConnections(t *testing.T) {
+	var newConns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"title": "ok"})
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL)
+	if err := hc.SetTransportConfig(10, 10, time.Minute); err != nil {
+		t.Fatalf("SetTransportConfig: %v", err)
+	}
+
+	transport, ok := hc.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", hc.httpClient.Transport)
+	}
+	if transport == http.DefaultTransport {
+		t.Error("SetTransportConfig modified http.DefaultTransport")
+	}
+	if transport.MaxIdleConnsPerHost != 10 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("transport not configured: %d %s", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := hc.GetTitle(); err != nil {
+			t.Fatalf("GetTitle: %v", err)
+		}
+	}
+	if n := newConns.Load(); n != 1 {
+		t.Errorf("opened %d connections for 5 sequential requests, want 1", n)
+	}
+}
+
+func TestSetTransportConfigCustomClient(t *testing.T) {
+	custom := &http.Client{}
+	hc := NewHTTPClient("http://localhost:3000", WithHTTPClient(custom))
+
+	if err := hc.SetTransportConfig(10, 10, time.Minute); err == nil {
+		t.Fatal("SetTransportConfig: expected error for a custom client")
+	}
+	if custom.Transport != nil {
+		t.Error("custom client's transport was modified")
+	}
+}
+
+func (f *fakeTransport) Stream(ctx context.Context, req *TransportRequest) (io.ReadCloser, error) {
+	call := f.record(req)
+	if f.stream == nil {
+		return nil, errors.New("stream not supported")
+	}
+	return f.stream(ctx, call)
+}
+
+func TestWithTimeoutOrderIndependent(t *testing.T) {
+	for _, name := range []string{"timeout first", "client first"} {
+		t.Run(name, func(t *testing.T) {
+			custom := &http.Client{}
+			opts := []Option{WithTimeout(time.Second), WithHTTPClient(custom)}
+			if name == "client first" {
+				opts = []Option{WithHTTPClient(custom), WithTimeout(time.Second)}
+			}
+
+			hc := NewHTTPClient("http://localhost:3000", opts...)
+			if hc.httpClient.Timeout != time.Second {
+				t.Errorf("Timeout = %s, want 1s", hc.httpClient.Timeout)
+			}
+			if custom.Timeout != 0 {
+				t.Errorf("caller's client was modified: Timeout = %s", custom.Timeout)
+			}
+		})
+	}
+}
+
+func TestRetriesSkipSentPOST(t *testing.T) {
+	var posts, gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts.Add(1)
+		} else {
+			gets.Add(1)
+		}
+		dropConnection(w)
+	}))
+	defer server.Close()
+
+	hc := NewHTTPClient(server.URL, WithRetries(2))
+
+	if err := hc.ElementClick("#submit"); err == nil {
+		t.Fatal("ElementClick: expected error")
+	}
+	if n := posts.Load(); n != 1 {
+		t.Errorf("POST sent %d times, want 1 (must not repeat a request the server received)", n)
+	}
+
+	if _, err := hc.GetTitle(); err == nil {
+		t.Fatal("GetTitle: expected error")
+	}
+	if n := gets.Load(); n != 3 {
+		t.Errorf("GET sent %d times, want 3", n)
+	}
+}
+
+func TestRetriesUnsentPOST(t *testing.T) {
+	var handled atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled.Add(1)
+		writeJSON(w, nil)
+	}))
+	defer server.Close()
+
+	// 第一次拨号失败，请求尚未发出，可以安全重试
+	var dials atomic.Int32
+	custom := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if dials.Add(1) == 1 {
+				return nil, errors.New("connection refused")
+			}
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+
+	hc := NewHTTPClient(server.URL, WithHTTPClient(custom), WithRetries(2))
+	if err := hc.ElementClick("#submit"); err != nil {
+		t.Fatalf("ElementClick: %v", err)
+	}
+	if n := dials.Load(); n != 2 {
+		t.Errorf("dialed %d times, want 2", n)
+	}
+	if n := handled.Load(); n != 1 {
+		t.Errorf("server handled %d clicks, want 1", n)
+	}
+}
@@ -0,0 +1,85 @@
+package cdpsdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultPollInterval 客户端轮询的默认间隔
+const defaultPollInterval = 200 * time.Millisecond
+
+// poll 按 interval 轮询 condition，直到其返回 true 或出错；超时返回 ErrTimeout
+func poll(ctx context.Context, timeout, interval time.Duration, condition func() (bool, error)) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := condition()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return ErrTimeout
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitUntil 在客户端按 interval 轮询 predicate，直到返回 true、出错或超时
+func (p *Page) WaitUntil(predicate func(*Page) (bool, error), timeout, interval time.Duration) error {
+	return p.WaitUntilContext(context.Background(), predicate, timeout, interval)
+}
+
+// WaitUntilContext 同 WaitUntil，ctx 取消时立即返回
+func (p *Page) WaitUntilContext(ctx context.Context, predicate func(*Page) (bool, error), timeout, interval time.Duration) error {
+	return poll(ctx, timeout, interval, func() (bool, error) {
+		return predicate(p)
+	})
+}
+
+// WaitForCount 轮询匹配元素数量，直到满足 op 条件：eq（等于）、gte（不少于）、lte（不多于）
+// timeout 为 0 时使用 Page.SetDefaultTimeout 设置的超时，均未设置时为 10 秒
+func (l *Locator) WaitForCount(count int, op string, timeout time.Duration) error {
+	var match func(int) bool
+	switch op {
+	case "eq":
+		match = func(n int) bool { return n == count }
+	case "gte":
+		match = func(n int) bool { return n >= count }
+	case "lte":
+		match = func(n int) bool { return n <= count }
+	default:
+		return fmt.Errorf("invalid count operator %q", op)
+	}
+
+	timeout = l.pollTimeout(timeout)
+	last := -1
+	err := poll(context.Background(), timeout, defaultPollInterval, func() (bool, error) {
+		n, err := l.Count()
+		if err != nil {
+			return false, err
+		}
+		last = n
+		return match(n), nil
+	})
+	if err == ErrTimeout {
+		return fmt.Errorf("wait for count %s %d on %s (last %d): %w", op, count, l.selector, last, err)
+	}
+	return err
+}
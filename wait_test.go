@@ -0,0 +1,94 @@
+package cdpsdk
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitUntil(t *testing.T) {
+	page, _ := newFakePage(t, nil)
+
+	var iterations int
+	err := page.WaitUntil(func(p *Page) (bool, error) {
+		iterations++
+		return iterations == 3, nil
+	}, time.Second, time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitUntil: %v", err)
+	}
+	if iterations != 3 {
+		t.Errorf("iterations = %d, want 3", iterations)
+	}
+
+	err = page.WaitUntil(func(p *Page) (bool, error) {
+		return false, nil
+	}, 20*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("WaitUntil error = %v, want ErrTimeout", err)
+	}
+
+	predicateErr := errors.New("page closed")
+	err = page.WaitUntil(func(p *Page) (bool, error) {
+		return false, predicateErr
+	}, time.Second, time.Millisecond)
+	if !errors.Is(err, predicateErr) {
+		t.Errorf("WaitUntil error = %v, want %v", err, predicateErr)
+	}
+}
+
+func TestWaitUntilContextCanceled(t *testing.T) {
+	page, _ := newFakePage(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := page.WaitUntilContext(ctx, func(p *Page) (bool, error) {
+		return false, nil
+	}, time.Second, time.Millisecond)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitUntilContext error = %v, want context.Canceled", err)
+	}
+}
+
+func TestWaitForCount(t *testing.T) {
+	counts := []int{0, 2, 5}
+	var step int
+	page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		count := counts[min(step, len(counts)-1)]
+		step++
+		return map[string]any{"count": count}, nil
+	})
+
+	if err := page.Locator(".result").WaitForCount(5, "gte", 2*time.Second); err != nil {
+		t.Fatalf("WaitForCount: %v", err)
+	}
+	if step != 3 {
+		t.Errorf("polled %d times, want 3", step)
+	}
+
+	step = 0
+	err := page.Locator(".result").WaitForCount(1, "eq", 50*time.Millisecond)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("WaitForCount error = %v, want ErrTimeout", err)
+	}
+	if !strings.Contains(err.Error(), "last") {
+		t.Errorf("error %q does not include the last count", err)
+	}
+
+	if err := page.Locator(".result").WaitForCount(1, "gt", time.Second); err == nil {
+		t.Error("WaitForCount: expected error for invalid operator")
+	}
+}
+
+func TestWaitForCountLte(t *testing.T) {
+	page, _ := newFakePage(t, func(call fakeCall) (map[string]any, error) {
+		return map[string]any{"count": 0}, nil
+	})
+
+	if err := page.Locator(".spinner").WaitForCount(0, "lte", time.Second); err != nil {
+		t.Errorf("WaitForCount: %v", err)
+	}
+}